@@ -0,0 +1,7 @@
+data "onepasswordorg_group_members" "owners" {
+  group_id = "Owners"
+}
+
+output "owner_emails" {
+  value = [for m in data.onepasswordorg_group_members.owners.members : m.email]
+}
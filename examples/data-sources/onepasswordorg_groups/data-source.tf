@@ -0,0 +1,7 @@
+data "onepasswordorg_groups" "teams" {
+  name_regex = "^team-"
+}
+
+output "team_group_ids" {
+  value = { for g in data.onepasswordorg_groups.teams.groups : g.name => g.id }
+}
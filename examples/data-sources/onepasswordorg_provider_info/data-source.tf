@@ -0,0 +1,9 @@
+data "onepasswordorg_provider_info" "current" {}
+
+output "op_version" {
+  value = data.onepasswordorg_provider_info.current.op_version
+}
+
+output "signed_in_user" {
+  value = data.onepasswordorg_provider_info.current.user_email
+}
@@ -0,0 +1,8 @@
+data "onepasswordorg_users" "engineering" {
+  email_domain = "slok.dev"
+  status       = "active"
+}
+
+output "engineering_user_ids" {
+  value = { for u in data.onepasswordorg_users.engineering.users : u.email => u.id }
+}
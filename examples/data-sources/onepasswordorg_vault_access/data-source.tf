@@ -0,0 +1,14 @@
+data "onepasswordorg_vault" "production" {
+  name = "production"
+}
+
+data "onepasswordorg_vault_access" "production" {
+  vault_id = data.onepasswordorg_vault.production.id
+}
+
+output "production_managers" {
+  value = concat(
+    [for g in data.onepasswordorg_vault_access.production.groups : g.group_id if g.permissions.manage_vault],
+    [for u in data.onepasswordorg_vault_access.production.users : u.user_id if u.permissions.manage_vault],
+  )
+}
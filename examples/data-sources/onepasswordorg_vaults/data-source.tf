@@ -0,0 +1,7 @@
+data "onepasswordorg_vaults" "platform" {
+  name_regex = "^platform-"
+}
+
+output "platform_vault_ids" {
+  value = { for v in data.onepasswordorg_vaults.platform.vaults : v.name => v.id }
+}
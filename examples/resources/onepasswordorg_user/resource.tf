@@ -2,3 +2,16 @@ resource "onepasswordorg_user" "user0" {
   name  = "User zero"
   email = "user0@slok.dev"
 }
+
+resource "onepasswordorg_user" "user1" {
+  name          = "User one"
+  email         = "user1@slok.dev"
+  state         = "suspended"
+  deletion_mode = "suspend"
+}
+
+resource "onepasswordorg_user" "contractor" {
+  name  = "Contractor"
+  email = "contractor@slok.dev"
+  type  = "guest"
+}
@@ -2,3 +2,13 @@ resource "onepasswordorg_vault" "test" {
   name        = "test-vault"
   description = "Test vault"
 }
+
+resource "onepasswordorg_vault" "platform" {
+  name                   = "platform"
+  description            = "Platform team vault"
+  icon                   = "gears"
+  allow_admins_to_manage = true
+
+  deletion_protection          = true
+  prevent_destroy_if_not_empty = true
+}
@@ -77,3 +77,13 @@ resource "onepasswordorg_vault_group_access" "business_manage" {
   }
 }
 
+resource "onepasswordorg_group" "group4" {
+  name        = "group-4"
+  description = "Group 4"
+}
+
+resource "onepasswordorg_vault_group_access" "preset_read_only" {
+  vault_id = onepasswordorg_vault.vault0.id
+  group_id = onepasswordorg_group.group4.id
+  preset   = "read_only"
+}
@@ -76,3 +76,14 @@ resource "onepasswordorg_vault_user_access" "business_manage" {
     manage_vault = true
   }
 }
+
+resource "onepasswordorg_user" "user4" {
+  name  = "user-4"
+  email = "user4@slok.dev"
+}
+
+resource "onepasswordorg_vault_user_access" "preset_read_only" {
+  vault_id = onepasswordorg_vault.vault0.id
+  user_id  = onepasswordorg_user.user4.id
+  preset   = "read_only"
+}
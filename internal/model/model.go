@@ -1,12 +1,38 @@
 package model
 
+import "time"
+
 // User represents a 1password user.
 type User struct {
-	ID    string
-	Email string
-	Name  string
+	ID        string
+	Email     string
+	Name      string
+	Type      UserType
+	State     UserState
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
+// UserType represents a 1password user account type.
+type UserType int
+
+const (
+	UserTypeMember UserType = iota
+	UserTypeGuest
+	UserTypeUnknown
+)
+
+// UserState represents a 1password user account state.
+type UserState int
+
+const (
+	UserStateActive UserState = iota
+	UserStateSuspended
+	// UserStatePending is a user that has been invited but has not joined yet.
+	UserStatePending
+	UserStateUnknown
+)
+
 // Group represents a 1password group.
 type Group struct {
 	ID          string
@@ -19,6 +45,9 @@ type Vault struct {
 	ID          string
 	Name        string
 	Description string
+	// Icon is the name of the vault icon (e.g: `vault-door`), empty means the default one.
+	Icon                string
+	AllowAdminsToManage bool
 }
 
 // MembershipRole represents a 1password user membership role.
@@ -36,6 +65,12 @@ type Membership struct {
 	Role    MembershipRole
 }
 
+// GroupMember represents a 1password user that is part of a group with a role.
+type GroupMember struct {
+	User User
+	Role MembershipRole
+}
+
 type VaultGroupAccess struct {
 	VaultID     string
 	GroupID     string
@@ -1,16 +1,37 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 )
 
 var permissionsAttribute = schema.SingleNestedAttribute{
-	Required:    true,
-	Description: `The permissions of the access. Note: Not all permissions are available in all plans, and some permissions require others. More info in [1password docs](https://developer.1password.com/docs/cli/vault-permissions/).`,
+	Optional:    true,
+	Computed:    true,
+	Description: `The permissions of the access, required if ` + "`preset`" + ` is not set or is ` + "`custom`" + `. Note: Not all permissions are available in all plans, and some permissions require others, the required permissions that are not set will be enabled automatically (e.g: ` + "`edit_items`" + ` enables ` + "`view_items`" + ` and ` + "`view_and_copy_passwords`" + `). More info in [1password docs](https://developer.1password.com/docs/cli/vault-permissions/).`,
+	Validators: []validator.Object{
+		accessPermissionsValidator{},
+	},
+	PlanModifiers: []planmodifier.Object{
+		accessPermissionsDependenciesPlanModifier{},
+	},
 	Attributes: map[string]schema.Attribute{
 		"allow_viewing":           schema.BoolAttribute{Computed: true, Optional: true, Default: booldefault.StaticBool(false)},
 		"allow_editing":           schema.BoolAttribute{Computed: true, Optional: true, Default: booldefault.StaticBool(false)},
@@ -30,6 +51,270 @@ var permissionsAttribute = schema.SingleNestedAttribute{
 	},
 }
 
+const (
+	accessPresetReadOnly  = "read_only"
+	accessPresetReadWrite = "read_write"
+	accessPresetAdmin     = "admin"
+	accessPresetCustom    = "custom"
+)
+
+var presetAttribute = schema.StringAttribute{
+	Optional: true,
+	Description: fmt.Sprintf("A set of permissions based on the provider `account_type`, one of `%s`, `%s`, `%s` or `%s`. "+
+		"`%s` allows viewing the items, `%s` also allows managing the items and `%s` also allows managing the vault. "+
+		"Use `%s` (or don't set it) to set the permissions with `permissions`.",
+		accessPresetReadOnly, accessPresetReadWrite, accessPresetAdmin, accessPresetCustom,
+		accessPresetReadOnly, accessPresetReadWrite, accessPresetAdmin, accessPresetCustom),
+	Validators: []validator.String{
+		stringvalidator.OneOf(accessPresetReadOnly, accessPresetReadWrite, accessPresetAdmin, accessPresetCustom),
+	},
+}
+
+var (
+	businessReadOnlyAccessPermissions = model.AccessPermissions{
+		ViewItems:            true,
+		ViewAndCopyPasswords: true,
+		ViewItemHistory:      true,
+	}
+	businessReadWriteAccessPermissions = model.AccessPermissions{
+		ViewItems:            true,
+		ViewAndCopyPasswords: true,
+		ViewItemHistory:      true,
+		CreateItems:          true,
+		EditItems:            true,
+		ArchiveItems:         true,
+		DeleteItems:          true,
+		ImportItems:          true,
+		ExportItems:          true,
+		CopyAndShareItems:    true,
+		PrintItems:           true,
+	}
+	businessAdminAccessPermissions = model.AccessPermissions{
+		ViewItems:            true,
+		ViewAndCopyPasswords: true,
+		ViewItemHistory:      true,
+		CreateItems:          true,
+		EditItems:            true,
+		ArchiveItems:         true,
+		DeleteItems:          true,
+		ImportItems:          true,
+		ExportItems:          true,
+		CopyAndShareItems:    true,
+		PrintItems:           true,
+		ManageVault:          true,
+	}
+	teamsReadOnlyAccessPermissions  = model.AccessPermissions{AllowViewing: true}
+	teamsReadWriteAccessPermissions = model.AccessPermissions{AllowViewing: true, AllowEditing: true}
+	teamsAdminAccessPermissions     = model.AccessPermissions{AllowViewing: true, AllowEditing: true, AllowManaging: true}
+)
+
+func getAccessPresetPermissions(accountType, preset string) (*model.AccessPermissions, error) {
+	var ps model.AccessPermissions
+	switch {
+	case accountType == accountTypeTeams && preset == accessPresetReadOnly:
+		ps = teamsReadOnlyAccessPermissions
+	case accountType == accountTypeTeams && preset == accessPresetReadWrite:
+		ps = teamsReadWriteAccessPermissions
+	case accountType == accountTypeTeams && preset == accessPresetAdmin:
+		ps = teamsAdminAccessPermissions
+	case preset == accessPresetReadOnly:
+		ps = businessReadOnlyAccessPermissions
+	case preset == accessPresetReadWrite:
+		ps = businessReadWriteAccessPermissions
+	case preset == accessPresetAdmin:
+		ps = businessAdminAccessPermissions
+	default:
+		return nil, fmt.Errorf("unknown preset %q", preset)
+	}
+
+	return &ps, nil
+}
+
+func isCustomAccessPreset(preset types.String) bool {
+	return preset.IsNull() || preset.ValueString() == accessPresetCustom
+}
+
+// validateAccessPresetConfig validates the permissions are set only when the preset is custom.
+func validateAccessPresetConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var preset types.String
+	var permissions types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root("preset"), &preset)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	if diags.HasError() || preset.IsUnknown() || permissions.IsUnknown() {
+		return
+	}
+
+	custom := isCustomAccessPreset(preset)
+	switch {
+	case custom && permissions.IsNull():
+		diags.AddAttributeError(path.Root("permissions"), "Missing permissions",
+			fmt.Sprintf("permissions are required when the preset is not set or is %q.", accessPresetCustom))
+	case !custom && !permissions.IsNull():
+		diags.AddAttributeError(path.Root("permissions"), "Invalid permissions",
+			fmt.Sprintf("permissions can't be set with %q preset, use %q preset instead.", preset.ValueString(), accessPresetCustom))
+	}
+}
+
+// modifyPlanAccessPreset sets the preset permissions on the plan.
+func modifyPlanAccessPreset(ctx context.Context, accountType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var preset types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("preset"), &preset)...)
+	if resp.Diagnostics.HasError() || preset.IsUnknown() || isCustomAccessPreset(preset) {
+		return
+	}
+
+	// Not configured provider (e.g: validating), use the default.
+	if accountType == "" {
+		accountType = accountTypeBusiness
+	}
+
+	ps, err := getAccessPresetPermissions(accountType, preset.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("preset"), "Invalid preset", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permissions"), mapModelToTfAccessPermissions(*ps))...)
+}
+
+// teamsAccessPermissions are the permissions of 1password teams plans, the rest are from business plans.
+var teamsAccessPermissions = []string{"allow_viewing", "allow_editing", "allow_managing"}
+
+// accessPermissionDependencies are the permissions required by each permission, 1password enables
+// them implicitly when granting the permission, so we need them to plan the permissions that 1password
+// will have.
+// More info in https://developer.1password.com/docs/cli/vault-permissions/.
+var accessPermissionDependencies = map[string][]string{
+	// Teams.
+	"allow_editing": {"allow_viewing"},
+
+	// Business.
+	"create_items":            {"view_items"},
+	"view_and_copy_passwords": {"view_items"},
+	"edit_items":              {"view_items", "view_and_copy_passwords"},
+	"archive_items":           {"view_items", "view_and_copy_passwords", "edit_items"},
+	"delete_items":            {"view_items", "view_and_copy_passwords", "edit_items"},
+	"view_item_history":       {"view_items", "view_and_copy_passwords"},
+	"import_items":            {"view_items", "create_items"},
+	"export_items":            {"view_items", "view_and_copy_passwords", "view_item_history"},
+	"copy_and_share_items":    {"view_items", "view_and_copy_passwords", "view_item_history"},
+	"print_items":             {"view_items", "view_and_copy_passwords", "view_item_history"},
+}
+
+func isAccessPermissionEnabled(v attr.Value) bool {
+	b, ok := v.(types.Bool)
+	return ok && b.ValueBool()
+}
+
+func isAccessPermissionDisabled(v attr.Value) bool {
+	b, ok := v.(types.Bool)
+	return ok && !b.IsNull() && !b.IsUnknown() && !b.ValueBool()
+}
+
+// accessPermissionsValidator validates that the permissions are from a single plan type and the
+// permissions required by the enabled ones have not been disabled explicitly.
+type accessPermissionsValidator struct{}
+
+func (v accessPermissionsValidator) Description(ctx context.Context) string {
+	return "permissions must be of the same plan type and can't disable permissions required by the enabled ones"
+}
+
+func (v accessPermissionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accessPermissionsValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	config := req.ConfigValue.Attributes()
+
+	// Check teams and business permissions are not mixed.
+	var teams, business []string
+	for _, name := range slices.Sorted(maps.Keys(config)) {
+		if !isAccessPermissionEnabled(config[name]) {
+			continue
+		}
+
+		if slices.Contains(teamsAccessPermissions, name) {
+			teams = append(teams, name)
+		} else {
+			business = append(business, name)
+		}
+	}
+
+	if len(teams) > 0 && len(business) > 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid permissions",
+			fmt.Sprintf("Teams permissions (%s) can't be used with business permissions (%s).", strings.Join(teams, ", "), strings.Join(business, ", ")))
+	}
+
+	// Check the required permissions are not disabled.
+	for _, name := range slices.Sorted(maps.Keys(accessPermissionDependencies)) {
+		if !isAccessPermissionEnabled(config[name]) {
+			continue
+		}
+
+		for _, dep := range accessPermissionDependencies[name] {
+			if isAccessPermissionDisabled(config[dep]) {
+				resp.Diagnostics.AddAttributeError(req.Path.AtName(dep), "Invalid permissions",
+					fmt.Sprintf("%q permission requires %q permission, it can't be disabled.", name, dep))
+			}
+		}
+	}
+}
+
+// accessPermissionsDependenciesPlanModifier enables on the plan the permissions required by the enabled ones
+// that are not set on the configuration, so the plan matches the permissions 1password will have.
+type accessPermissionsDependenciesPlanModifier struct{}
+
+func (m accessPermissionsDependenciesPlanModifier) Description(ctx context.Context) string {
+	return "enables the permissions required by the enabled permissions"
+}
+
+func (m accessPermissionsDependenciesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m accessPermissionsDependenciesPlanModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	config := req.ConfigValue.Attributes()
+	plan := maps.Clone(req.PlanValue.Attributes())
+	changed := false
+	for name, deps := range accessPermissionDependencies {
+		if !isAccessPermissionEnabled(config[name]) {
+			continue
+		}
+
+		for _, dep := range deps {
+			if config[dep].IsNull() && !isAccessPermissionEnabled(plan[dep]) {
+				plan[dep] = types.BoolValue(true)
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	planValue, diags := types.ObjectValue(req.PlanValue.AttributeTypes(ctx), plan)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.PlanValue = planValue
+}
+
 func mapTfToModelAccessPermissions(ap AccessPermissions) model.AccessPermissions {
 	return model.AccessPermissions{
 		AllowViewing:         ap.AllowViewing.ValueBool(),
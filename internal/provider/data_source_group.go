@@ -21,7 +21,8 @@ func NewGroupDataSource() datasource.DataSource {
 }
 
 type groupDataSource struct {
-	repo storage.Repository
+	repo                 storage.Repository
+	descriptionSupported bool
 }
 
 func (d *groupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -58,6 +59,7 @@ func (d *groupDataSource) Configure(ctx context.Context, req datasource.Configur
 	}
 
 	d.repo = appServices.Repository
+	d.descriptionSupported = appServices.GroupDescriptionSupported
 }
 
 func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -76,7 +78,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	newTfGroup := mapModelToTfGroup(*group)
+	newTfGroup := mapModelToTfGroup(*group, d.descriptionSupported)
 
 	diags = resp.State.Set(ctx, newTfGroup)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+var (
+	_ datasource.DataSource              = &groupMembersDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupMembersDataSource{}
+)
+
+func NewGroupMembersDataSource() datasource.DataSource {
+	return &groupMembersDataSource{}
+}
+
+type groupMembersDataSource struct {
+	repo storage.Repository
+}
+
+func (d *groupMembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_members"
+}
+
+func (d groupMembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about the members of a 1password group.
+
+Can be used with groups that are not managed by Terraform (e.g: the built-in ` + "`Owners`" + ` or ` + "`Administrators`" + ` groups).
+`,
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Description: "The group ID.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"members": schema.ListNestedAttribute{
+				Description: "The members of the group.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Description: "The user ID.",
+							Computed:    true,
+						},
+						"email": schema.StringAttribute{
+							Description: "The email of the user.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "The role of the user on the group (`member` or `manager`).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *groupMembersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.repo = appServices.Repository
+}
+
+func (d *groupMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Retrieve values.
+	var tfMembers GroupMembers
+	diags := req.Config.Get(ctx, &tfMembers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get members.
+	groupID := tfMembers.GroupID.ValueString()
+	members, err := d.repo.ListGroupMembers(ctx, groupID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting group members", fmt.Sprintf("Could not list group %q members, unexpected error: %s", groupID, err.Error()))
+		return
+	}
+
+	tfMembers.Members = make([]GroupMember, 0, len(members))
+	for _, m := range members {
+		tfMember, err := mapModelToTfGroupMember(groupID, m)
+		if err != nil {
+			resp.Diagnostics.AddError("Error mapping member", "Could not map member:"+err.Error())
+			return
+		}
+		tfMembers.Members = append(tfMembers.Members, *tfMember)
+	}
+
+	diags = resp.State.Set(ctx, tfMembers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func mapModelToTfGroupMember(groupID string, m model.GroupMember) (*GroupMember, error) {
+	// Reuse the membership mapping for the role.
+	membership, err := mapModelToTfMembership(model.Membership{GroupID: groupID, UserID: m.User.ID, Role: m.Role})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GroupMember{
+		UserID: types.StringValue(m.User.ID),
+		Email:  types.StringValue(m.User.Email),
+		Role:   membership.Role,
+	}, nil
+}
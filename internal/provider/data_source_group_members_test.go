@@ -0,0 +1,60 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceGroupMembersCorrect will check the members of a group can be used as data source.
+func TestAccDataSourceGroupMembersCorrect(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccDataSourceGroupMembersCorrect")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+data "onepasswordorg_group_members" "test" {
+  group_id = "Owners"
+}
+
+data "onepasswordorg_group_members" "empty" {
+  group_id = "Administrators"
+}
+`
+	// Prepare storage.
+	repo := getFakeRepository(t)
+	for _, email := range []string{"user0@slok.dev", "user1@slok.dev"} {
+		_, err := repo.CreateUser(context.TODO(), model.User{Email: email, Name: email})
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "Owners", UserID: "user0@slok.dev", Role: model.MembershipRoleManager}))
+	require.NoError(t, repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "Owners", UserID: "user1@slok.dev", Role: model.MembershipRoleMember}))
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.#", "2"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.0.user_id", "user0@slok.dev"), // Fake uses user email ID.
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.0.email", "user0@slok.dev"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.0.role", "manager"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.1.user_id", "user1@slok.dev"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.test", "members.1.role", "member"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_group_members.empty", "members.#", "0"),
+				),
+			},
+		},
+	})
+}
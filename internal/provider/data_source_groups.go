@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+var (
+	_ datasource.DataSource              = &groupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupsDataSource{}
+)
+
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+type groupsDataSource struct {
+	repo                 storage.Repository
+	descriptionSupported bool
+}
+
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about the 1password groups.
+`,
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return the groups whose name matches this regex.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "The groups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the group.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the group.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the group (not set with the SCIM backend).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *groupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.repo = appServices.Repository
+	d.descriptionSupported = appServices.GroupDescriptionSupported
+}
+
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Retrieve values.
+	var tfGroups Groups
+	diags := req.Config.Get(ctx, &tfGroups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileOptionalRegexp(tfGroups.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regex", err.Error())
+		return
+	}
+
+	// Get groups.
+	groups, err := d.repo.ListGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting groups", "Could not list groups, unexpected error: "+err.Error())
+		return
+	}
+
+	// Filter groups.
+	tfGroups.Groups = []Group{}
+	for _, g := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(g.Name) {
+			continue
+		}
+
+		tfGroups.Groups = append(tfGroups.Groups, mapModelToTfGroup(g, d.descriptionSupported))
+	}
+
+	diags = resp.State.Set(ctx, tfGroups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceGroupsCorrect will check the groups can be listed and filtered as data source.
+func TestAccDataSourceGroupsCorrect(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccDataSourceGroupsCorrect")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+data "onepasswordorg_groups" "all" {}
+
+data "onepasswordorg_groups" "filtered" {
+  name_regex = "^team-"
+}
+`
+	// Prepare storage.
+	repo := getFakeRepository(t)
+	for _, name := range []string{"team-a", "team-b", "other"} {
+		_, err := repo.CreateGroup(context.TODO(), model.Group{Name: name, Description: "Test " + name})
+		require.NoError(t, err)
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.all", "groups.#", "3"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.filtered", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.filtered", "groups.0.id", "team-a"), // Fake uses name as ID.
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.filtered", "groups.0.name", "team-a"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.filtered", "groups.0.description", "Test team-a"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_groups.filtered", "groups.1.name", "team-b"),
+				),
+			},
+		},
+	})
+}
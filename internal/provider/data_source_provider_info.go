@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+)
+
+var (
+	_ datasource.DataSource              = &providerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &providerInfoDataSource{}
+)
+
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &providerInfoDataSource{}
+}
+
+type providerInfoDataSource struct {
+	backend string
+	opInfo  *onepasswordcli.OpInfo
+}
+
+func (d *providerInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+func (d providerInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about the provider backend, the detected op CLI version and the signed in account.
+
+The op CLI and account attributes are only set when the provider uses the op CLI.
+`,
+		Attributes: map[string]schema.Attribute{
+			"backend": schema.StringAttribute{
+				Description: "The backend used by the provider to manage 1password (`op`, `scim` or `fake`).",
+				Computed:    true,
+			},
+			"op_version": schema.StringAttribute{
+				Description: "The detected op CLI version.",
+				Computed:    true,
+			},
+			"account_url": schema.StringAttribute{
+				Description: "The URL of the signed in 1password account.",
+				Computed:    true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "The ID of the signed in 1password account.",
+				Computed:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the signed in user.",
+				Computed:    true,
+			},
+			"user_email": schema.StringAttribute{
+				Description: "The email of the signed in user, not set for service accounts.",
+				Computed:    true,
+			},
+			"user_type": schema.StringAttribute{
+				Description: "The type of the signed in user (e.g: `HUMAN`, `SERVICE_ACCOUNT`).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *providerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.backend = appServices.Backend
+	d.opInfo = appServices.OpInfo
+}
+
+func (d *providerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tfInfo := mapModelToTfProviderInfo(d.backend, d.opInfo)
+
+	diags := resp.State.Set(ctx, tfInfo)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func mapModelToTfProviderInfo(backend string, opInfo *onepasswordcli.OpInfo) ProviderInfo {
+	info := ProviderInfo{
+		Backend:    types.StringValue(backend),
+		OpVersion:  types.StringNull(),
+		AccountURL: types.StringNull(),
+		AccountID:  types.StringNull(),
+		UserID:     types.StringNull(),
+		UserEmail:  types.StringNull(),
+		UserType:   types.StringNull(),
+	}
+
+	if opInfo == nil {
+		return info
+	}
+
+	info.OpVersion = types.StringValue(opInfo.Version)
+	info.AccountURL = types.StringValue(opInfo.AccountURL)
+	info.AccountID = types.StringValue(opInfo.AccountUUID)
+	info.UserID = types.StringValue(opInfo.UserUUID)
+	info.UserType = types.StringValue(opInfo.UserType)
+	if opInfo.UserEmail != "" {
+		info.UserEmail = types.StringValue(opInfo.UserEmail)
+	}
+
+	return info
+}
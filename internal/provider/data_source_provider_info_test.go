@@ -0,0 +1,39 @@
+package provider_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceProviderInfoCorrect will check the provider information can be used as data source.
+func TestAccDataSourceProviderInfoCorrect(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccDataSourceProviderInfoCorrect")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+data "onepasswordorg_provider_info" "test" {}
+`
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onepasswordorg_provider_info.test", "backend", "fake"),
+					resource.TestCheckNoResourceAttr("data.onepasswordorg_provider_info.test", "op_version"),
+					resource.TestCheckNoResourceAttr("data.onepasswordorg_provider_info.test", "account_url"),
+				),
+			},
+		},
+	})
+}
@@ -47,6 +47,22 @@ Provides information about a 1password user.
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the user (`member`, `guest` or `unknown`).",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the user (`active`, `suspended`, `pending` or `unknown`), `pending` users are being transferred to another account.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The creation date of the user in RFC3339 format.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last update date of the user in RFC3339 format.",
+			},
 		},
 	}
 }
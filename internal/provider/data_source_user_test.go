@@ -43,6 +43,10 @@ data "onepasswordorg_user" "test" {
 					resource.TestCheckResourceAttr("data.onepasswordorg_user.test", "id", "test@slok.dev"), // Fake uses user email ID.
 					resource.TestCheckResourceAttr("data.onepasswordorg_user.test", "email", "test@slok.dev"),
 					resource.TestCheckResourceAttr("data.onepasswordorg_user.test", "name", "Test user"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_user.test", "type", "member"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_user.test", "status", "active"),
+					resource.TestCheckResourceAttrSet("data.onepasswordorg_user.test", "created_at"),
+					resource.TestCheckResourceAttrSet("data.onepasswordorg_user.test", "updated_at"),
 				),
 			},
 		},
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+var (
+	_ datasource.DataSource              = &usersDataSource{}
+	_ datasource.DataSourceWithConfigure = &usersDataSource{}
+)
+
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+type usersDataSource struct {
+	repo storage.Repository
+}
+
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about the 1password users.
+
+The users can be filtered, when multiple filters are set, the users need to match all of them.
+`,
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return the users whose name matches this regex.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"email_domain": schema.StringAttribute{
+				Description: "Only return the users whose email is of this domain (e.g: `slok.dev`).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return the users with this status (can be `active`, `suspended` or `pending`).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfUserStatusActive, tfUserStatusSuspended, tfUserStatusPending),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "Only return the users that are members of this group.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "The users.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the user.",
+							Computed:    true,
+						},
+						"email": schema.StringAttribute{
+							Description: "The email of the user.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the user.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the user (`member`, `guest` or `unknown`).",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the user (`active`, `suspended`, `pending` or `unknown`).",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The creation date of the user in RFC3339 format.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The last update date of the user in RFC3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *usersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.repo = appServices.Repository
+}
+
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Retrieve values.
+	var tfUsers Users
+	diags := req.Config.Get(ctx, &tfUsers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileOptionalRegexp(tfUsers.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regex", err.Error())
+		return
+	}
+
+	// Get users, if we filter by group, we only need the group members.
+	var users []model.User
+	if groupID := tfUsers.GroupID.ValueString(); groupID != "" {
+		members, err := d.repo.ListGroupMembers(ctx, groupID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting users", fmt.Sprintf("Could not list group %q members, unexpected error: %s", groupID, err.Error()))
+			return
+		}
+		for _, m := range members {
+			users = append(users, m.User)
+		}
+	} else {
+		users, err = d.repo.ListUsers(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting users", "Could not list users, unexpected error: "+err.Error())
+			return
+		}
+	}
+
+	// Filter users.
+	emailDomainSuffix := "@" + strings.ToLower(tfUsers.EmailDomain.ValueString())
+	tfUsers.Users = []User{}
+	for _, u := range users {
+		tfUser := mapModelToTfUser(u)
+
+		if nameRegex != nil && !nameRegex.MatchString(u.Name) {
+			continue
+		}
+
+		if !tfUsers.EmailDomain.IsNull() && !strings.HasSuffix(strings.ToLower(u.Email), emailDomainSuffix) {
+			continue
+		}
+
+		if !tfUsers.Status.IsNull() && !tfUsers.Status.Equal(tfUser.Status) {
+			continue
+		}
+
+		tfUsers.Users = append(tfUsers.Users, tfUser)
+	}
+
+	diags = resp.State.Set(ctx, tfUsers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// compileOptionalRegexp compiles the regex of an optional attribute, if not set it will return nil.
+func compileOptionalRegexp(v types.String) (*regexp.Regexp, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+
+	r, err := regexp.Compile(v.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", v.ValueString(), err)
+	}
+
+	return r, nil
+}
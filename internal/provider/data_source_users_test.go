@@ -0,0 +1,135 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceUsersCorrect will check the users can be listed and filtered as data source.
+func TestAccDataSourceUsersCorrect(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		expEmails []string
+		expErr    *regexp.Regexp
+	}{
+		"Without filters, should return all the users.": {
+			config: `
+data "onepasswordorg_users" "test" {}
+`,
+			expEmails: []string{"user0@slok.dev", "user1@slok.dev", "user2@other.dev"},
+		},
+
+		"Filtering by name regex, should return the users that match.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  name_regex = "^Test user [01]$"
+}
+`,
+			expEmails: []string{"user0@slok.dev", "user1@slok.dev"},
+		},
+
+		"Filtering by email domain, should return the users of the domain.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  email_domain = "other.dev"
+}
+`,
+			expEmails: []string{"user2@other.dev"},
+		},
+
+		"Filtering by status, should return the users with the status.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  status = "suspended"
+}
+`,
+			expEmails: []string{"user1@slok.dev"},
+		},
+
+		"Filtering by group, should return the members of the group.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  group_id = "test-group"
+}
+`,
+			expEmails: []string{"user0@slok.dev", "user2@other.dev"},
+		},
+
+		"Filtering by multiple filters, should return the users that match all of them.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  group_id     = "test-group"
+  email_domain = "slok.dev"
+}
+`,
+			expEmails: []string{"user0@slok.dev"},
+		},
+
+		"An invalid name regex should fail.": {
+			config: `
+data "onepasswordorg_users" "test" {
+  name_regex = "["
+}
+`,
+			expErr: regexp.MustCompile(`Invalid name regex`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Prepare fake storage.
+			path, delete := getFakeRepoTmpFile("TestAccDataSourceUsersCorrect")
+			defer delete()
+			_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+			// Prepare storage.
+			repo := getFakeRepository(t)
+			for _, u := range []model.User{
+				{Email: "user0@slok.dev", Name: "Test user 0"},
+				{Email: "user1@slok.dev", Name: "Test user 1"},
+				{Email: "user2@other.dev", Name: "Other user 2"},
+			} {
+				_, err := repo.CreateUser(context.TODO(), u)
+				require.NoError(t, err)
+			}
+			require.NoError(t, repo.SuspendUser(context.TODO(), "user1@slok.dev"))
+			require.NoError(t, repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "test-group", UserID: "user0@slok.dev"}))
+			require.NoError(t, repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "test-group", UserID: "user2@other.dev"}))
+
+			// Prepare non error checks.
+			var checks resource.TestCheckFunc
+			if test.expErr == nil {
+				cs := []resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("data.onepasswordorg_users.test", "users.#", strconv.Itoa(len(test.expEmails))),
+				}
+				for i, email := range test.expEmails {
+					cs = append(cs, resource.TestCheckResourceAttr("data.onepasswordorg_users.test", fmt.Sprintf("users.%d.email", i), email))
+				}
+				checks = resource.ComposeAggregateTestCheckFunc(cs...)
+			}
+
+			// Execute test.
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      test.config,
+						Check:       checks,
+						ExpectError: test.expErr,
+					},
+				},
+			})
+		})
+	}
+}
@@ -21,7 +21,8 @@ func NewVaultDataSource() datasource.DataSource {
 }
 
 type vaultDataSource struct {
-	repo storage.Repository
+	repo                  storage.Repository
+	settingsReadSupported bool
 }
 
 func (d *vaultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,6 +46,14 @@ Provides information about a 1password vault.
 				Description: "The description of the vault.",
 				Computed:    true,
 			},
+			"icon": schema.StringAttribute{
+				Description: "The icon of the vault (not set with the op backend, op doesn't return it).",
+				Computed:    true,
+			},
+			"allow_admins_to_manage": schema.BoolAttribute{
+				Description: "If the administrators can manage the vault (not set with the op backend, op doesn't return it).",
+				Computed:    true,
+			},
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
@@ -59,6 +68,7 @@ func (d *vaultDataSource) Configure(ctx context.Context, req datasource.Configur
 	}
 
 	d.repo = appServices.Repository
+	d.settingsReadSupported = appServices.VaultSettingsReadSupported
 }
 
 func (d *vaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -77,7 +87,7 @@ func (d *vaultDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	newTfVault := mapModelToTfVault(*vault)
+	newTfVault := mapModelToTfVault(*vault, d.settingsReadSupported)
 
 	diags = resp.State.Set(ctx, newTfVault)
 	resp.Diagnostics.Append(diags...)
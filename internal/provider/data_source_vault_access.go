@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+var (
+	_ datasource.DataSource              = &vaultAccessDataSource{}
+	_ datasource.DataSourceWithConfigure = &vaultAccessDataSource{}
+)
+
+func NewVaultAccessDataSource() datasource.DataSource {
+	return &vaultAccessDataSource{}
+}
+
+type vaultAccessDataSource struct {
+	repo storage.Repository
+}
+
+func (d *vaultAccessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_access"
+}
+
+var dataSourcePermissionsAttribute = schema.SingleNestedAttribute{
+	Description: "The permissions of the access. More info in [1password docs](https://developer.1password.com/docs/cli/vault-permissions/).",
+	Computed:    true,
+	Attributes: map[string]schema.Attribute{
+		"allow_viewing":           schema.BoolAttribute{Computed: true},
+		"allow_editing":           schema.BoolAttribute{Computed: true},
+		"allow_managing":          schema.BoolAttribute{Computed: true},
+		"view_items":              schema.BoolAttribute{Computed: true},
+		"create_items":            schema.BoolAttribute{Computed: true},
+		"edit_items":              schema.BoolAttribute{Computed: true},
+		"archive_items":           schema.BoolAttribute{Computed: true},
+		"delete_items":            schema.BoolAttribute{Computed: true},
+		"view_and_copy_passwords": schema.BoolAttribute{Computed: true},
+		"view_item_history":       schema.BoolAttribute{Computed: true},
+		"import_items":            schema.BoolAttribute{Computed: true},
+		"export_items":            schema.BoolAttribute{Computed: true},
+		"copy_and_share_items":    schema.BoolAttribute{Computed: true},
+		"print_items":             schema.BoolAttribute{Computed: true},
+		"manage_vault":            schema.BoolAttribute{Computed: true},
+	},
+}
+
+func (d vaultAccessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about all the group and user accesses of a 1password vault.
+
+Can be used to audit the accesses of vaults, including the ones not managed by Terraform.
+`,
+		Attributes: map[string]schema.Attribute{
+			"vault_id": schema.StringAttribute{
+				Description: "The vault ID.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "The group accesses of the vault.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_id": schema.StringAttribute{
+							Description: "The group ID.",
+							Computed:    true,
+						},
+						"permissions": dataSourcePermissionsAttribute,
+					},
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "The user accesses of the vault.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Description: "The user ID.",
+							Computed:    true,
+						},
+						"permissions": dataSourcePermissionsAttribute,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vaultAccessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.repo = appServices.Repository
+}
+
+func (d *vaultAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Retrieve values.
+	var tfAccess VaultAccess
+	diags := req.Config.Get(ctx, &tfAccess)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get accesses.
+	vaultID := tfAccess.VaultID.ValueString()
+	groupAccesses, err := d.repo.ListVaultGroupAccesses(ctx, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting vault group accesses", fmt.Sprintf("Could not list vault %q group accesses, unexpected error: %s", vaultID, err.Error()))
+		return
+	}
+
+	userAccesses, err := d.repo.ListVaultUserAccesses(ctx, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting vault user accesses", fmt.Sprintf("Could not list vault %q user accesses, unexpected error: %s", vaultID, err.Error()))
+		return
+	}
+
+	tfAccess.Groups = make([]VaultAccessGroup, 0, len(groupAccesses))
+	for _, a := range groupAccesses {
+		tfAccess.Groups = append(tfAccess.Groups, VaultAccessGroup{
+			GroupID:     types.StringValue(a.GroupID),
+			Permissions: mapModelToTfAccessPermissions(a.Permissions),
+		})
+	}
+
+	tfAccess.Users = make([]VaultAccessUser, 0, len(userAccesses))
+	for _, a := range userAccesses {
+		tfAccess.Users = append(tfAccess.Users, VaultAccessUser{
+			UserID:      types.StringValue(a.UserID),
+			Permissions: mapModelToTfAccessPermissions(a.Permissions),
+		})
+	}
+
+	diags = resp.State.Set(ctx, tfAccess)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceVaultAccessCorrect will check the accesses of a vault can be used as data source.
+func TestAccDataSourceVaultAccessCorrect(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccDataSourceVaultAccessCorrect")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+data "onepasswordorg_vault_access" "test" {
+  vault_id = "vault-0"
+}
+
+data "onepasswordorg_vault_access" "empty" {
+  vault_id = "vault-1"
+}
+`
+	// Prepare storage.
+	repo := getFakeRepository(t)
+	require.NoError(t, repo.EnsureVaultGroupAccess(context.TODO(), model.VaultGroupAccess{
+		VaultID:     "vault-0",
+		GroupID:     "group-0",
+		Permissions: model.AccessPermissions{AllowViewing: true, ViewItems: true},
+	}))
+	require.NoError(t, repo.EnsureVaultGroupAccess(context.TODO(), model.VaultGroupAccess{
+		VaultID:     "vault-0",
+		GroupID:     "group-1",
+		Permissions: model.AccessPermissions{AllowManaging: true, ManageVault: true},
+	}))
+	require.NoError(t, repo.EnsureVaultUserAccess(context.TODO(), model.VaultUserAccess{
+		VaultID:     "vault-0",
+		UserID:      "user0@slok.dev",
+		Permissions: model.AccessPermissions{AllowEditing: true, EditItems: true},
+	}))
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.0.group_id", "group-0"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.0.permissions.allow_viewing", "true"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.0.permissions.view_items", "true"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.0.permissions.manage_vault", "false"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.1.group_id", "group-1"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "groups.1.permissions.manage_vault", "true"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "users.0.user_id", "user0@slok.dev"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "users.0.permissions.edit_items", "true"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.test", "users.0.permissions.view_items", "false"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.empty", "groups.#", "0"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault_access.empty", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
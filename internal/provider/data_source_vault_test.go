@@ -28,7 +28,7 @@ data "onepasswordorg_vault" "test" {
 `
 	// Prepare storage.
 	repo := getFakeRepository(t)
-	_, err := repo.CreateVault(context.TODO(), model.Vault{Name: "test-vault", Description: "Test vault"})
+	_, err := repo.CreateVault(context.TODO(), model.Vault{Name: "test-vault", Description: "Test vault", Icon: "gears", AllowAdminsToManage: true})
 	require.NoError(t, err)
 	defer func() { _ = repo.DeleteVault(context.TODO(), "test-vault") }()
 
@@ -43,6 +43,8 @@ data "onepasswordorg_vault" "test" {
 					resource.TestCheckResourceAttr("data.onepasswordorg_vault.test", "id", "test-vault"), // Fake uses user name ID.
 					resource.TestCheckResourceAttr("data.onepasswordorg_vault.test", "description", "Test vault"),
 					resource.TestCheckResourceAttr("data.onepasswordorg_vault.test", "name", "test-vault"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault.test", "icon", "gears"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vault.test", "allow_admins_to_manage", "true"),
 				),
 			},
 		},
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+var (
+	_ datasource.DataSource              = &vaultsDataSource{}
+	_ datasource.DataSourceWithConfigure = &vaultsDataSource{}
+)
+
+func NewVaultsDataSource() datasource.DataSource {
+	return &vaultsDataSource{}
+}
+
+type vaultsDataSource struct {
+	repo                  storage.Repository
+	settingsReadSupported bool
+}
+
+func (d *vaultsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vaults"
+}
+
+func (d vaultsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides information about the 1password vaults.
+`,
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return the vaults whose name matches this regex.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vaults": schema.ListNestedAttribute{
+				Description: "The vaults.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the vault.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the vault.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the vault.",
+							Computed:    true,
+						},
+						"icon": schema.StringAttribute{
+							Description: "The icon of the vault (not set with the op backend, op doesn't return it).",
+							Computed:    true,
+						},
+						"allow_admins_to_manage": schema.BoolAttribute{
+							Description: "If the administrators can manage the vault (not set with the op backend, op doesn't return it).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vaultsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	appServices := getAppServicesFromDatasourceRequest(&req)
+	if appServices == nil {
+		return
+	}
+
+	d.repo = appServices.Repository
+	d.settingsReadSupported = appServices.VaultSettingsReadSupported
+}
+
+func (d *vaultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Retrieve values.
+	var tfVaults Vaults
+	diags := req.Config.Get(ctx, &tfVaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileOptionalRegexp(tfVaults.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regex", err.Error())
+		return
+	}
+
+	// Get vaults.
+	vaults, err := d.repo.ListVaults(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting vaults", "Could not list vaults, unexpected error: "+err.Error())
+		return
+	}
+
+	// Filter vaults.
+	tfVaults.Vaults = []Vault{}
+	for _, v := range vaults {
+		if nameRegex != nil && !nameRegex.MatchString(v.Name) {
+			continue
+		}
+
+		tfVaults.Vaults = append(tfVaults.Vaults, mapModelToTfVault(v, d.settingsReadSupported))
+	}
+
+	diags = resp.State.Set(ctx, tfVaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+)
+
+// TestAccDataSourceVaultsCorrect will check the vaults can be listed and filtered as data source.
+func TestAccDataSourceVaultsCorrect(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccDataSourceVaultsCorrect")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+data "onepasswordorg_vaults" "all" {}
+
+data "onepasswordorg_vaults" "filtered" {
+  name_regex = "^team-"
+}
+`
+	// Prepare storage.
+	repo := getFakeRepository(t)
+	for _, name := range []string{"team-a", "team-b", "other"} {
+		_, err := repo.CreateVault(context.TODO(), model.Vault{Name: name, Description: "Test " + name})
+		require.NoError(t, err)
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.all", "vaults.#", "3"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.filtered", "vaults.#", "2"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.filtered", "vaults.0.id", "team-a"), // Fake uses name as ID.
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.filtered", "vaults.0.name", "team-a"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.filtered", "vaults.0.description", "Test team-a"),
+					resource.TestCheckResourceAttr("data.onepasswordorg_vaults.filtered", "vaults.1.name", "team-b"),
+				),
+			},
+		},
+	})
+}
@@ -2,8 +2,14 @@ package provider_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -12,6 +18,84 @@ import (
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 )
 
+// testSCIMServer is an in memory SCIM bridge that only serves groups (and filters them by name), used to test the resources with
+// the SCIM backend.
+type testSCIMServer struct {
+	URL string
+
+	mu     sync.Mutex
+	groups map[string]map[string]interface{}
+}
+
+// newTestSCIMServer returns a running SCIM server, the SCIM base URL is `URL`.
+func newTestSCIMServer(t *testing.T) *testSCIMServer {
+	s := &testSCIMServer{groups: map[string]map[string]interface{}{}}
+	srv := httptest.NewServer(http.HandlerFunc(s.serveGroups))
+	t.Cleanup(srv.Close)
+	s.URL = srv.URL + "/scim/v2"
+
+	return s
+}
+
+// group returns the group stored on the server.
+func (s *testSCIMServer) group(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.groups[id]
+}
+
+func (s *testSCIMServer) serveGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := strings.CutPrefix(r.URL.Path, "/scim/v2/Groups/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/scim/v2/Groups":
+		group := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		// Use the name as ID like the fake storage.
+		group["id"] = group["displayName"]
+		s.groups[group["id"].(string)] = group
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(group)
+	case r.Method == http.MethodGet && r.URL.Path == "/scim/v2/Groups":
+		// Only the display name filter is supported.
+		name, _ := strings.CutPrefix(r.URL.Query().Get("filter"), "displayName eq ")
+		groups := []interface{}{}
+		for _, g := range s.groups {
+			if `"`+g["displayName"].(string)+`"` == name {
+				groups = append(groups, g)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalResults": len(groups),
+			"startIndex":   1,
+			"itemsPerPage": len(groups),
+			"Resources":    groups,
+		})
+	case r.Method == http.MethodGet && s.groups[id] != nil:
+		_ = json.NewEncoder(w).Encode(s.groups[id])
+	case r.Method == http.MethodPatch && s.groups[id] != nil:
+		patch := struct {
+			Operations []struct {
+				Path  string      `json:"path"`
+				Value interface{} `json:"value"`
+			} `json:"Operations"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		for _, op := range patch.Operations {
+			s.groups[id][op.Path] = op.Value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && s.groups[id] != nil:
+		delete(s.groups, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
 // getFakeRepoTmpFile returns a temp file that can be used for the fake repository storage.
 // It returns the file path and a delete file function.
 func getFakeRepoTmpFile(prefix string) (path string, delete func()) {
@@ -30,7 +114,11 @@ func assertUserOnFakeStorage(t *testing.T, expUser *model.User) resource.TestChe
 		repo := getFakeRepository(t)
 
 		gotUser, err := repo.GetUserByID(context.TODO(), expUser.ID)
-		assert.NoError(err)
+		if assert.NoError(err) {
+			// Dates are set by the storage.
+			gotUser.CreatedAt = time.Time{}
+			gotUser.UpdatedAt = time.Time{}
+		}
 		assert.Equal(expUser, gotUser)
 		return nil
 	})
@@ -1,74 +1,176 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 )
 
 type User struct {
-	ID    types.String `tfsdk:"id"`
-	Email types.String `tfsdk:"email"`
-	Name  types.String `tfsdk:"name"`
+	ID        types.String `tfsdk:"id"`
+	Email     types.String `tfsdk:"email"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Status    types.String `tfsdk:"status"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
 }
 
 func mapModelToTfUser(u model.User) User {
 	return User{
-		ID:    types.StringValue(u.ID),
-		Email: types.StringValue(u.Email),
-		Name:  types.StringValue(u.Name),
+		ID:        types.StringValue(u.ID),
+		Email:     types.StringValue(u.Email),
+		Name:      types.StringValue(u.Name),
+		Type:      types.StringValue(mapModelToTfUserType(u.Type)),
+		Status:    types.StringValue(mapModelToTfUserStatus(u.State)),
+		CreatedAt: mapModelToTfTime(u.CreatedAt),
+		UpdatedAt: mapModelToTfTime(u.UpdatedAt),
 	}
 }
 
-func mapTfToModelUser(u User) model.User {
-	return model.User{
-		ID:    u.ID.ValueString(),
-		Email: u.Email.ValueString(),
-		Name:  u.Name.ValueString(),
+const (
+	tfUserTypeMember  = "member"
+	tfUserTypeGuest   = "guest"
+	tfUserTypeUnknown = "unknown"
+
+	tfUserStatusActive    = "active"
+	tfUserStatusSuspended = "suspended"
+	tfUserStatusPending   = "pending"
+	tfUserStatusUnknown   = "unknown"
+)
+
+func mapModelToTfUserType(t model.UserType) string {
+	switch t {
+	case model.UserTypeMember:
+		return tfUserTypeMember
+	case model.UserTypeGuest:
+		return tfUserTypeGuest
+	default:
+		return tfUserTypeUnknown
 	}
 }
 
+func mapModelToTfUserStatus(s model.UserState) string {
+	switch s {
+	case model.UserStateActive:
+		return tfUserStatusActive
+	case model.UserStateSuspended:
+		return tfUserStatusSuspended
+	case model.UserStatePending:
+		return tfUserStatusPending
+	default:
+		return tfUserStatusUnknown
+	}
+}
+
+// mapModelToTfTime maps a time to an RFC3339 string, unset times will be null.
+func mapModelToTfTime(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+// Users is the users data source data, the filters and the users that match them.
+type Users struct {
+	NameRegex   types.String `tfsdk:"name_regex"`
+	EmailDomain types.String `tfsdk:"email_domain"`
+	Status      types.String `tfsdk:"status"`
+	GroupID     types.String `tfsdk:"group_id"`
+	Users       []User       `tfsdk:"users"`
+}
+
+// UserResource is the user resource data, the user data with the resource settings.
+type UserResource struct {
+	ID           types.String `tfsdk:"id"`
+	Email        types.String `tfsdk:"email"`
+	Name         types.String `tfsdk:"name"`
+	State        types.String `tfsdk:"state"`
+	DeletionMode types.String `tfsdk:"deletion_mode"`
+	Type         types.String `tfsdk:"type"`
+	Status       types.String `tfsdk:"status"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
 type Group struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 }
 
-func mapModelToTfGroup(g model.Group) Group {
+// mapModelToTfGroup maps the group, if the group description is not supported by the repository it's
+// set as null.
+func mapModelToTfGroup(g model.Group, descriptionSupported bool) Group {
+	description := types.StringNull()
+	if descriptionSupported {
+		description = types.StringValue(g.Description)
+	}
+
 	return Group{
 		ID:          types.StringValue(g.ID),
 		Name:        types.StringValue(g.Name),
-		Description: types.StringValue(g.Description),
+		Description: description,
 	}
 }
 
-func mapTfToModelGroup(g Group) model.Group {
-	return model.Group{
-		ID:          g.ID.ValueString(),
-		Name:        g.Name.ValueString(),
-		Description: g.Description.ValueString(),
-	}
+// Groups is the groups data source data, the filters and the groups that match them.
+type Groups struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Groups    []Group      `tfsdk:"groups"`
+}
+
+// GroupResource is the group resource data, the group data with the resource settings.
+type GroupResource struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type Vault struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	Icon                types.String `tfsdk:"icon"`
+	AllowAdminsToManage types.Bool   `tfsdk:"allow_admins_to_manage"`
 }
 
-func mapModelToTfVault(u model.Vault) Vault {
+// mapModelToTfVault maps the vault, if the vault settings are not supported by the repository they are
+// unknown and set as null.
+func mapModelToTfVault(u model.Vault, settingsReadSupported bool) Vault {
+	icon := types.StringNull()
+	allowAdminsToManage := types.BoolNull()
+	if settingsReadSupported {
+		icon = types.StringValue(u.Icon)
+		allowAdminsToManage = types.BoolValue(u.AllowAdminsToManage)
+	}
+
 	return Vault{
-		ID:          types.StringValue(u.ID),
-		Name:        types.StringValue(u.Name),
-		Description: types.StringValue(u.Description),
+		ID:                  types.StringValue(u.ID),
+		Name:                types.StringValue(u.Name),
+		Description:         types.StringValue(u.Description),
+		Icon:                icon,
+		AllowAdminsToManage: allowAdminsToManage,
 	}
 }
 
-func mapTfToModelVault(v Vault) model.Vault {
-	return model.Vault{
-		ID:          v.ID.ValueString(),
-		Name:        v.Name.ValueString(),
-		Description: v.Description.ValueString(),
-	}
+// Vaults is the vaults data source data, the filters and the vaults that match them.
+type Vaults struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Vaults    []Vault      `tfsdk:"vaults"`
+}
+
+// VaultResource is the vault resource data, the vault data with the resource settings.
+type VaultResource struct {
+	ID                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	Description              types.String `tfsdk:"description"`
+	Icon                     types.String `tfsdk:"icon"`
+	AllowAdminsToManage      types.Bool   `tfsdk:"allow_admins_to_manage"`
+	DeletionProtection       types.Bool   `tfsdk:"deletion_protection"`
+	PreventDestroyIfNotEmpty types.Bool   `tfsdk:"prevent_destroy_if_not_empty"`
 }
 
 type Member struct {
@@ -78,16 +180,47 @@ type Member struct {
 	Role    types.String `tfsdk:"role"`
 }
 
+// GroupMembers is the group members data source data.
+type GroupMembers struct {
+	GroupID types.String  `tfsdk:"group_id"`
+	Members []GroupMember `tfsdk:"members"`
+}
+
+type GroupMember struct {
+	UserID types.String `tfsdk:"user_id"`
+	Email  types.String `tfsdk:"email"`
+	Role   types.String `tfsdk:"role"`
+}
+
 type VaultGroupAccess struct {
 	ID          types.String       `tfsdk:"id"`
 	VaultID     types.String       `tfsdk:"vault_id"`
 	GroupID     types.String       `tfsdk:"group_id"`
+	Preset      types.String       `tfsdk:"preset"`
 	Permissions *AccessPermissions `tfsdk:"permissions"`
 }
 
 type VaultUserAccess struct {
 	ID          types.String       `tfsdk:"id"`
 	VaultID     types.String       `tfsdk:"vault_id"`
+	UserID      types.String       `tfsdk:"user_id"`
+	Preset      types.String       `tfsdk:"preset"`
+	Permissions *AccessPermissions `tfsdk:"permissions"`
+}
+
+// VaultAccess is the vault access data source data.
+type VaultAccess struct {
+	VaultID types.String       `tfsdk:"vault_id"`
+	Groups  []VaultAccessGroup `tfsdk:"groups"`
+	Users   []VaultAccessUser  `tfsdk:"users"`
+}
+
+type VaultAccessGroup struct {
+	GroupID     types.String       `tfsdk:"group_id"`
+	Permissions *AccessPermissions `tfsdk:"permissions"`
+}
+
+type VaultAccessUser struct {
 	UserID      types.String       `tfsdk:"user_id"`
 	Permissions *AccessPermissions `tfsdk:"permissions"`
 }
@@ -109,3 +242,14 @@ type AccessPermissions struct {
 	PrintItems           types.Bool `tfsdk:"print_items"`
 	ManageVault          types.Bool `tfsdk:"manage_vault"`
 }
+
+// ProviderInfo is the provider info data source data.
+type ProviderInfo struct {
+	Backend    types.String `tfsdk:"backend"`
+	OpVersion  types.String `tfsdk:"op_version"`
+	AccountURL types.String `tfsdk:"account_url"`
+	AccountID  types.String `tfsdk:"account_id"`
+	UserID     types.String `tfsdk:"user_id"`
+	UserEmail  types.String `tfsdk:"user_email"`
+	UserType   types.String `tfsdk:"user_type"`
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/cache"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/fake"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/scim"
 )
 
 const (
@@ -21,10 +25,36 @@ const (
 	envVarOpEmail           = "OP_EMAIL"
 	envVarOpSecretKey       = "OP_SECRET_KEY"
 	envVarOpPassword        = "OP_PASSWORD"
+	envVarOpServiceAccount  = "OP_SERVICE_ACCOUNT_TOKEN"
+	envVarOpAccount         = "OP_ACCOUNT"
+	envVarOpSCIMURL         = "OP_SCIM_URL"
+	envVarOpSCIMToken       = "OP_SCIM_TOKEN"
 	EnvVarOpFakeStoragePath = "OP_FAKE_STORAGE_PATH"
 	EnvVarOpCliPath         = "OP_CLI_PATH"
 )
 
+const (
+	defaultMaxRetries   = 5
+	defaultRetryMaxWait = 2 * time.Minute
+)
+
+const (
+	authModeCredentials     = "credentials"
+	authModeServiceAccount  = "service_account"
+	authModeExistingSession = "existing_session"
+)
+
+const (
+	backendFake  = "fake"
+	backendSCIM  = "scim"
+	backendOpCli = "op"
+)
+
+const (
+	accountTypeBusiness = "business"
+	accountTypeTeams    = "teams"
+)
+
 func New() provider.Provider {
 	return &onePasswordOrgProvider{}
 }
@@ -49,6 +79,8 @@ grouping users into teams (groups in 1password).
 This provider needs [op](https://1password.com/downloads/command-line/) v2.x Cli, thats why it doesn't use 1password connect
 API and needs a real 1password account as the authentication.
 
+The op version is checked when the provider is configured, using a service account requires op v2.18 or newer.
+
 ## Authentication
 
 Needs a real 1password account so the provider can use the "password" and "secret key" of that account.
@@ -56,10 +88,24 @@ Needs a real 1password account so the provider can use the "password" and "secre
 A recommended way would be creating an account in the 1password organization/company only for automation
 like Terraform (used by this provider).
 
+A [service account](https://developer.1password.com/docs/service-accounts/) token can be used instead of the
+account credentials, in that case "address", "email", "secret key" and "password" are not required.
+
+An already signed in op account (e.g: op desktop app integration or ` + "`OP_SESSION_*`" + ` env vars on developer machines) can be
+reused setting ` + "`auth_mode`" + ` to ` + "`existing_session`" + `, in that case only the optional "account" and "session token" are used.
+
+## SCIM bridge
+
+Users, groups and group members can be managed using the [1password SCIM bridge](https://support.1password.com/scim/)
+instead of the op CLI, setting the SCIM bridge URL and bearer token. In this case op and the account credentials
+are not required, however SCIM doesn't support vaults, vault accesses, guest users, group managers nor group descriptions
+(the group descriptions are ignored).
+
 ## Terraform cloud
 
 The provider will detect that its executing in terraform cloud and will use the embedded op CLI for this purpose
-so it satisfies the op Cli requirement inside Terraform cloud workers.
+so it satisfies the op Cli requirement inside Terraform cloud workers. The embedded op CLI can be used outside Terraform
+cloud with ` + "`use_embedded_op_cli`" + ` (only linux amd64 and arm64 are embedded).
 `,
 		Attributes: map[string]schema.Attribute{
 			"address": schema.StringAttribute{
@@ -80,6 +126,53 @@ so it satisfies the op Cli requirement inside Terraform cloud workers.
 				Sensitive:   true,
 				Description: fmt.Sprintf("Set account 1password password. Also `%s` env var can be used.", envVarOpPassword),
 			},
+			"auth_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How the provider authenticates on 1password, one of `%s` (account credentials), `%s` (service account token) or `%s` (already signed in op account). By default `%s` if the service account token is set, otherwise `%s`.", authModeCredentials, authModeServiceAccount, authModeExistingSession, authModeServiceAccount, authModeCredentials),
+			},
+			"account": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Set the op account (shorthand, sign in address or ID) of the existing session, used with `%s` auth mode (by default the op default account). Also `%s` env var can be used.", authModeExistingSession, envVarOpAccount),
+			},
+			"session_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Set the op session token of the account, used with `%s` auth mode, requires `account` (by default op will use its own session, e.g: desktop app integration or `OP_SESSION_*` env vars).", authModeExistingSession),
+			},
+			"service_account_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Set 1password service account token, if set it will be used instead of the account credentials. Also `%s` env var can be used.", envVarOpServiceAccount),
+			},
+			"scim_url": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Set the 1password SCIM bridge API URL (e.g: https://scim.example.com/scim/v2), if set the SCIM bridge will be used instead of the op CLI. Also `%s` env var can be used.", envVarOpSCIMURL),
+			},
+			"scim_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Set the 1password SCIM bridge bearer token, required if `scim_url` is set. Also `%s` env var can be used.", envVarOpSCIMToken),
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of retries of an op command that failed with an API throttling error, `0` disables retries (by default `%d`).", defaultMaxRetries),
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum time spent retrying an op command as a duration (e.g: `30s`, `5m`), `0` means no limit (by default `%s`).", defaultRetryMaxWait),
+			},
+			"max_concurrent_op_commands": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of op commands executing at the same time, the rest will wait until they can be executed (by default unlimited).",
+			},
+			"account_type": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The 1password account plan type, one of `%s` or `%s`. Used to know the permissions of the vault access presets (by default `%s`).", accountTypeBusiness, accountTypeTeams, accountTypeBusiness),
+			},
+			"enable_read_cache": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Caches the group members and vault accesses lists while the provider runs, so reading many members of the same group or accesses of the same vault lists them only once instead of once per resource (by default `false`).",
+			},
 			"fake_storage_path": schema.StringAttribute{
 				Optional:    true,
 				Description: fmt.Sprintf("File to a path where the provider will store the data as if it is 1password (this is used only on development). Also `%s` env var can be used.", EnvVarOpFakeStoragePath),
@@ -88,18 +181,34 @@ so it satisfies the op Cli requirement inside Terraform cloud workers.
 				Optional:    true,
 				Description: fmt.Sprintf("The path that points to the op cli binary. Also `%s` env var can be used. (by default `op` on system path, ignored if run in Terraform cloud).", EnvVarOpCliPath),
 			},
+			"use_embedded_op_cli": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the op cli binary embedded in the provider instead of the system one, can't be used with `op_cli_path` (by default `false`, always used if run in Terraform cloud). Only linux amd64 and arm64 have an embedded op cli.",
+			},
 		},
 	}
 }
 
 // Provider configuration.
 type providerData struct {
-	Address         types.String `tfsdk:"address"`
-	Email           types.String `tfsdk:"email"`
-	SecretKey       types.String `tfsdk:"secret_key"`
-	Password        types.String `tfsdk:"password"`
-	FakeStoragePath types.String `tfsdk:"fake_storage_path"`
-	CliPath         types.String `tfsdk:"op_cli_path"`
+	Address             types.String `tfsdk:"address"`
+	Email               types.String `tfsdk:"email"`
+	SecretKey           types.String `tfsdk:"secret_key"`
+	Password            types.String `tfsdk:"password"`
+	ServiceAccountToken types.String `tfsdk:"service_account_token"`
+	AuthMode            types.String `tfsdk:"auth_mode"`
+	Account             types.String `tfsdk:"account"`
+	SessionToken        types.String `tfsdk:"session_token"`
+	SCIMURL             types.String `tfsdk:"scim_url"`
+	SCIMToken           types.String `tfsdk:"scim_token"`
+	MaxRetries          types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait        types.String `tfsdk:"retry_max_wait"`
+	MaxConcurrentOpCmds types.Int64  `tfsdk:"max_concurrent_op_commands"`
+	AccountType         types.String `tfsdk:"account_type"`
+	EnableReadCache     types.Bool   `tfsdk:"enable_read_cache"`
+	FakeStoragePath     types.String `tfsdk:"fake_storage_path"`
+	CliPath             types.String `tfsdk:"op_cli_path"`
+	UseEmbeddedCli      types.Bool   `tfsdk:"use_embedded_op_cli"`
 }
 
 func (p *onePasswordOrgProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -117,52 +226,197 @@ func (p *onePasswordOrgProvider) Configure(ctx context.Context, req provider.Con
 		createErrSummary = "Unable to create op client"
 	)
 
+	accountType, err := p.configureAccountType(config)
+	if err != nil {
+		resp.Diagnostics.AddError(configErrSummary, "Invalid account type:\n\n"+err.Error())
+	}
+
 	// Get if we are in fake mode.
 	fakeStoragePath, err := p.configureFakeStoragePath(config)
 	if err != nil {
 		resp.Diagnostics.AddError(configErrSummary, "Invalid fake storage path:\n\n"+err.Error())
 	}
 
-	// Create fake or regular mode.
+	scimURL, err := p.configureSCIMURL(config)
+	if err != nil {
+		resp.Diagnostics.AddError(configErrSummary, "Invalid SCIM URL:\n\n"+err.Error())
+	}
+
+	// Create fake, SCIM or regular mode.
 	// If the user has set the fake storage path then we are going to use a fake repository.
+	// If the user has set the SCIM URL then we are going to use the SCIM bridge based repository.
 	// If the user didn't, we will use the op cli based repository (a.k.a real 1password APIs).
 	var repo storage.Repository
-	if fakeStoragePath != "" {
+	var backend string
+	var opInfo *onepasswordcli.OpInfo
+	// op can't change the email of the users.
+	userEmailUpdateSupported := false
+	// op doesn't return the vault icon nor if the admins can manage the vault.
+	vaultSettingsReadSupported := false
+	// SCIM groups don't have description.
+	groupDescriptionSupported := true
+	switch {
+	case fakeStoragePath != "":
+		backend = backendFake
+		userEmailUpdateSupported = true
+		vaultSettingsReadSupported = true
 		repo, err = fake.NewRepository(fakeStoragePath)
 		if err != nil {
 			resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password fake storage:\n\n"+err.Error())
 			return
 		}
-	} else {
-		address, err := p.configureAddress(config)
+	case scimURL != "":
+		scimToken, err := p.configureSCIMToken(config)
 		if err != nil {
-			resp.Diagnostics.AddError(configErrSummary, "Invalid address:\n\n"+err.Error())
+			resp.Diagnostics.AddError(configErrSummary, "Invalid SCIM token:\n\n"+err.Error())
+			return
 		}
 
-		email, err := p.configureEmail(config)
+		backend = backendSCIM
+		userEmailUpdateSupported = true
+		groupDescriptionSupported = false
+		repo, err = scim.NewRepository(scim.RepositoryConfig{
+			URL:   scimURL,
+			Token: scimToken,
+		})
 		if err != nil {
-			resp.Diagnostics.AddError(configErrSummary, "Invalid email:\n\n"+err.Error())
+			resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password SCIM repository:\n\n"+err.Error())
+			return
+		}
+	default:
+		backend = backendOpCli
+		cliPath, err := p.configureCliPath(config)
+		if err != nil {
+			resp.Diagnostics.AddError(configErrSummary, "Invalid cli path:\n\n"+err.Error())
 		}
 
-		secretKey, err := p.configureSecretKey(config)
+		useEmbeddedCli, err := p.configureUseEmbeddedCli(config, cliPath)
 		if err != nil {
-			resp.Diagnostics.AddError(configErrSummary, "Invalid secret key:\n\n"+err.Error())
+			resp.Diagnostics.AddError(configErrSummary, "Invalid use embedded cli:\n\n"+err.Error())
 		}
 
-		password, err := p.configurePassword(config)
+		serviceAccountToken, err := p.configureServiceAccountToken(config)
 		if err != nil {
-			resp.Diagnostics.AddError(configErrSummary, "Invalid password:\n\n"+err.Error())
+			resp.Diagnostics.AddError(configErrSummary, "Invalid service account token:\n\n"+err.Error())
 		}
 
-		cliPath, err := p.configureCliPath(config)
+		authMode, err := p.configureAuthMode(config, serviceAccountToken)
 		if err != nil {
-			resp.Diagnostics.AddError(configErrSummary, "Invalid cli path:\n\n"+err.Error())
+			resp.Diagnostics.AddError(configErrSummary, "Invalid auth mode:\n\n"+err.Error())
+			return
+		}
+
+		// Validate the op commands settings before signing in, so invalid settings fail fast.
+		maxConcurrentOpCmds, err := p.configureMaxConcurrentOpCmds(config)
+		if err != nil {
+			resp.Diagnostics.AddError(configErrSummary, "Invalid max concurrent op commands:\n\n"+err.Error())
+		}
+
+		maxRetries, err := p.configureMaxRetries(config)
+		if err != nil {
+			resp.Diagnostics.AddError(configErrSummary, "Invalid max retries:\n\n"+err.Error())
+		}
+
+		retryMaxWait, err := p.configureRetryMaxWait(config)
+		if err != nil {
+			resp.Diagnostics.AddError(configErrSummary, "Invalid retry max wait:\n\n"+err.Error())
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
 		}
 
 		// Create OP cli.
-		cli, err := onepasswordcli.NewOpCli(cliPath, address, email, secretKey, password)
+		// Each auth mode only needs its own inputs.
+		var cli onepasswordcli.OpCli
+		switch authMode {
+		case authModeServiceAccount:
+			if serviceAccountToken == "" {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid service account token:\n\nservice account token cannot be an empty string")
+				return
+			}
+
+			cli, opInfo, err = onepasswordcli.NewServiceAccountOpCli(cliPath, useEmbeddedCli, serviceAccountToken)
+			if err != nil {
+				resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd client:\n\n"+err.Error())
+				return
+			}
+		case authModeExistingSession:
+			account, err := p.configureAccount(config)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid account:\n\n"+err.Error())
+			}
+
+			sessionToken, err := p.configureSessionToken(config, account)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid session token:\n\n"+err.Error())
+			}
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			cli, opInfo, err = onepasswordcli.NewExistingSessionOpCli(cliPath, useEmbeddedCli, account, sessionToken)
+			if err != nil {
+				resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd client:\n\n"+err.Error())
+				return
+			}
+		default:
+			address, err := p.configureAddress(config)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid address:\n\n"+err.Error())
+			}
+
+			email, err := p.configureEmail(config)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid email:\n\n"+err.Error())
+			}
+
+			secretKey, err := p.configureSecretKey(config)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid secret key:\n\n"+err.Error())
+			}
+
+			password, err := p.configurePassword(config)
+			if err != nil {
+				resp.Diagnostics.AddError(configErrSummary, "Invalid password:\n\n"+err.Error())
+			}
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			cli, opInfo, err = onepasswordcli.NewOpCli(cliPath, useEmbeddedCli, address, email, secretKey, password)
+			if err != nil {
+				resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd client:\n\n"+err.Error())
+				return
+			}
+		}
+
+		tflog.Debug(ctx, "op CLI signed in", map[string]interface{}{
+			"op_version":  opInfo.Version,
+			"account_url": opInfo.AccountURL,
+			"user_uuid":   opInfo.UserUUID,
+			"user_email":  opInfo.UserEmail,
+			"user_type":   opInfo.UserType,
+		})
+
+		// Limit the op commands executed at the same time.
+		if maxConcurrentOpCmds > 0 {
+			cli, err = onepasswordcli.NewConcurrencyLimitOpCli(cli, maxConcurrentOpCmds)
+			if err != nil {
+				resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd concurrency limit client:\n\n"+err.Error())
+				return
+			}
+		}
+
+		// Retry the op commands that failed with API throttling errors.
+		cli, err = onepasswordcli.NewRetryOpCli(cli, onepasswordcli.RetryOpCliConfig{
+			MaxRetries: maxRetries,
+			MaxWait:    retryMaxWait,
+		})
 		if err != nil {
-			resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd client:\n\n"+err.Error())
+			resp.Diagnostics.AddError(createErrSummary, "Unable to create 1password op cmd retry client:\n\n"+err.Error())
 			return
 		}
 
@@ -174,8 +428,19 @@ func (p *onePasswordOrgProvider) Configure(ctx context.Context, req provider.Con
 		}
 	}
 
+	// Cache the list reads that are repeated on every membership and vault access read.
+	if config.EnableReadCache.ValueBool() {
+		repo = cache.NewRepository(repo)
+	}
+
 	providerAppServices := providerAppServices{
-		Repository: repo,
+		Repository:                 repo,
+		AccountType:                accountType,
+		UserEmailUpdateSupported:   userEmailUpdateSupported,
+		VaultSettingsReadSupported: vaultSettingsReadSupported,
+		GroupDescriptionSupported:  groupDescriptionSupported,
+		Backend:                    backend,
+		OpInfo:                     opInfo,
 	}
 	resp.DataSourceData = providerAppServices
 	resp.ResourceData = providerAppServices
@@ -261,6 +526,178 @@ func (p *onePasswordOrgProvider) configurePassword(config providerData) (string,
 	return password, nil
 }
 
+func (p *onePasswordOrgProvider) configureServiceAccountToken(config providerData) (string, error) {
+	if config.ServiceAccountToken.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as service account token")
+	}
+
+	// If not set get from env, the value has priority.
+	// Service account is optional, so an empty token is valid.
+	if config.ServiceAccountToken.IsNull() {
+		return os.Getenv(envVarOpServiceAccount), nil
+	}
+
+	return config.ServiceAccountToken.ValueString(), nil
+}
+
+func (p *onePasswordOrgProvider) configureAuthMode(config providerData, serviceAccountToken string) (string, error) {
+	if config.AuthMode.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as auth mode")
+	}
+
+	// If not set, infer it from the service account token.
+	if config.AuthMode.IsNull() {
+		if serviceAccountToken != "" {
+			return authModeServiceAccount, nil
+		}
+		return authModeCredentials, nil
+	}
+
+	authMode := config.AuthMode.ValueString()
+	switch authMode {
+	case authModeCredentials, authModeServiceAccount, authModeExistingSession:
+		return authMode, nil
+	default:
+		return "", fmt.Errorf("auth mode must be %q, %q or %q, got %q", authModeCredentials, authModeServiceAccount, authModeExistingSession, authMode)
+	}
+}
+
+func (p *onePasswordOrgProvider) configureAccount(config providerData) (string, error) {
+	if config.Account.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as account")
+	}
+
+	// If not set get from env, the value has priority.
+	// Account is optional, op will use the default one.
+	if config.Account.IsNull() {
+		return os.Getenv(envVarOpAccount), nil
+	}
+
+	return config.Account.ValueString(), nil
+}
+
+func (p *onePasswordOrgProvider) configureSessionToken(config providerData, account string) (string, error) {
+	if config.SessionToken.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as session token")
+	}
+
+	// Session token is optional, op will use its own session.
+	sessionToken := config.SessionToken.ValueString()
+	if sessionToken != "" && account == "" {
+		return "", fmt.Errorf("account is required to use a session token")
+	}
+
+	return sessionToken, nil
+}
+
+func (p *onePasswordOrgProvider) configureSCIMURL(config providerData) (string, error) {
+	if config.SCIMURL.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as SCIM URL")
+	}
+
+	// If not set get from env, the value has priority.
+	// SCIM is optional, so an empty URL is valid.
+	if config.SCIMURL.IsNull() {
+		return os.Getenv(envVarOpSCIMURL), nil
+	}
+
+	return config.SCIMURL.ValueString(), nil
+}
+
+func (p *onePasswordOrgProvider) configureSCIMToken(config providerData) (string, error) {
+	if config.SCIMToken.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as SCIM token")
+	}
+
+	// If not set get from env, the value has priority.
+	var token string
+	if config.SCIMToken.IsNull() {
+		token = os.Getenv(envVarOpSCIMToken)
+	} else {
+		token = config.SCIMToken.ValueString()
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("SCIM token cannot be an empty string")
+	}
+
+	return token, nil
+}
+
+func (p *onePasswordOrgProvider) configureMaxRetries(config providerData) (int, error) {
+	if config.MaxRetries.IsUnknown() {
+		return 0, fmt.Errorf("cannot use unknown value as max retries")
+	}
+
+	if config.MaxRetries.IsNull() {
+		return defaultMaxRetries, nil
+	}
+
+	maxRetries := config.MaxRetries.ValueInt64()
+	if maxRetries < 0 {
+		return 0, fmt.Errorf("max retries can't be negative")
+	}
+
+	return int(maxRetries), nil
+}
+
+func (p *onePasswordOrgProvider) configureRetryMaxWait(config providerData) (time.Duration, error) {
+	if config.RetryMaxWait.IsUnknown() {
+		return 0, fmt.Errorf("cannot use unknown value as retry max wait")
+	}
+
+	if config.RetryMaxWait.IsNull() {
+		return defaultRetryMaxWait, nil
+	}
+
+	maxWait, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %w", err)
+	}
+
+	if maxWait < 0 {
+		return 0, fmt.Errorf("retry max wait can't be negative")
+	}
+
+	return maxWait, nil
+}
+
+func (p *onePasswordOrgProvider) configureMaxConcurrentOpCmds(config providerData) (int, error) {
+	if config.MaxConcurrentOpCmds.IsUnknown() {
+		return 0, fmt.Errorf("cannot use unknown value as max concurrent op commands")
+	}
+
+	// Not set means unlimited.
+	if config.MaxConcurrentOpCmds.IsNull() {
+		return 0, nil
+	}
+
+	maxConcurrent := config.MaxConcurrentOpCmds.ValueInt64()
+	if maxConcurrent < 1 {
+		return 0, fmt.Errorf("max concurrent op commands must be at least 1")
+	}
+
+	return int(maxConcurrent), nil
+}
+
+func (p *onePasswordOrgProvider) configureAccountType(config providerData) (string, error) {
+	if config.AccountType.IsUnknown() {
+		return "", fmt.Errorf("cannot use unknown value as account type")
+	}
+
+	if config.AccountType.IsNull() {
+		return accountTypeBusiness, nil
+	}
+
+	accountType := config.AccountType.ValueString()
+	switch accountType {
+	case accountTypeBusiness, accountTypeTeams:
+		return accountType, nil
+	default:
+		return "", fmt.Errorf("account type must be %q or %q, got %q", accountTypeBusiness, accountTypeTeams, accountType)
+	}
+}
+
 func (p *onePasswordOrgProvider) configureFakeStoragePath(config providerData) (string, error) {
 	// If not set get from env, the value has priority.
 	var fakePath string
@@ -276,7 +713,7 @@ func (p *onePasswordOrgProvider) configureFakeStoragePath(config providerData) (
 func (p *onePasswordOrgProvider) configureCliPath(config providerData) (string, error) {
 	// If not set get from env, the value has priority.
 	var cliPath string
-	if config.FakeStoragePath.IsNull() {
+	if config.CliPath.IsNull() {
 		cliPath = os.Getenv(EnvVarOpCliPath)
 	} else {
 		cliPath = config.CliPath.ValueString()
@@ -285,6 +722,28 @@ func (p *onePasswordOrgProvider) configureCliPath(config providerData) (string,
 	return cliPath, nil
 }
 
+func (p *onePasswordOrgProvider) configureUseEmbeddedCli(config providerData, cliPath string) (bool, error) {
+	if config.UseEmbeddedCli.IsUnknown() {
+		return false, fmt.Errorf("cannot use unknown value as use embedded cli")
+	}
+
+	useEmbedded := config.UseEmbeddedCli.ValueBool()
+	if !useEmbedded {
+		return false, nil
+	}
+
+	if cliPath != "" {
+		return false, fmt.Errorf("embedded cli can't be used with a custom cli path")
+	}
+
+	err := onepasswordcli.CheckEmbeddedOpCliPlatform()
+	if err != nil {
+		return false, fmt.Errorf("%w, install op and use `op_cli_path` instead", err)
+	}
+
+	return true, nil
+}
+
 func (p *onePasswordOrgProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewVaultResource,
@@ -299,11 +758,29 @@ func (p *onePasswordOrgProvider) Resources(ctx context.Context) []func() resourc
 func (p *onePasswordOrgProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewVaultDataSource,
+		NewVaultsDataSource,
+		NewVaultAccessDataSource,
 		NewUserDataSource,
+		NewUsersDataSource,
 		NewGroupDataSource,
+		NewGroupsDataSource,
+		NewGroupMembersDataSource,
+		NewProviderInfoDataSource,
 	}
 }
 
 type providerAppServices struct {
-	Repository storage.Repository
+	Repository  storage.Repository
+	AccountType string
+	// UserEmailUpdateSupported is true when the repository can change the email of the users.
+	UserEmailUpdateSupported bool
+	// VaultSettingsReadSupported is true when the repository returns the vault icon and if the admins can
+	// manage the vault.
+	VaultSettingsReadSupported bool
+	// GroupDescriptionSupported is true when the repository stores the group descriptions.
+	GroupDescriptionSupported bool
+	// Backend is the kind of repository (`op`, `scim` or `fake`).
+	Backend string
+	// OpInfo is the op CLI and signed in account information, only set with the op backend.
+	OpInfo *onepasswordcli.OpInfo
 }
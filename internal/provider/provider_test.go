@@ -1,15 +1,24 @@
 package provider_test
 
 import (
+	"context"
 	"os"
+	"strings"
 	"testing"
 
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/fake"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclitest"
 )
 
 // Acceptance tests don't run against onepassword, they use a fake file based storage.
@@ -48,3 +57,182 @@ func getFakeRepository(t *testing.T) storage.Repository {
 
 	return r
 }
+
+// setFakeVaultItems sets the number of items of a vault on the fake repository.
+func setFakeVaultItems(t *testing.T, vaultID string, items int) {
+	r, ok := getFakeRepository(t).(interface {
+		SetVaultItems(ctx context.Context, id string, items int) error
+	})
+	if !ok {
+		t.Fatalf("fake repository can't set vault items")
+	}
+
+	err := r.SetVaultItems(context.TODO(), vaultID, items)
+	if err != nil {
+		t.Fatalf("could not set vault items: %s", err)
+	}
+}
+
+// newTestProviderConfig returns the provider configuration with the attributes set, the rest of
+// attributes are null.
+func newTestProviderConfig(t *testing.T, p fwprovider.Provider, attrs map[string]interface{}) tfsdk.Config {
+	schemaResp := &fwprovider.SchemaResponse{}
+	p.Schema(context.TODO(), fwprovider.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	objType := schemaResp.Schema.Type().TerraformType(context.TODO()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+		if v, ok := attrs[name]; ok {
+			values[name] = tftypes.NewValue(typ, v)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objType, values),
+	}
+}
+
+func TestProviderConfigureServiceAccount(t *testing.T) {
+	tests := map[string]struct {
+		envToken string
+		attrs    map[string]interface{}
+		expCalls []string
+		expErr   string
+	}{
+		"Not setting the service account token, should use the env var token.": {
+			envToken: "token-env",
+			expCalls: []string{"token-env|whoami --format json"},
+		},
+
+		"Setting the service account token, should have priority over the env var token.": {
+			envToken: "token-env",
+			attrs:    map[string]interface{}{"service_account_token": "token-attr"},
+			expCalls: []string{"token-attr|whoami --format json"},
+		},
+
+		"Using the service account auth mode with an empty token, should fail.": {
+			attrs:  map[string]interface{}{"auth_mode": "service_account", "service_account_token": ""},
+			expErr: "service account token cannot be an empty string",
+		},
+
+		"Using the service account auth mode without token, should fail.": {
+			attrs:  map[string]interface{}{"auth_mode": "service_account"},
+			expErr: "service account token cannot be an empty string",
+		},
+
+		"Setting the service account token and the account credentials, should use the service account.": {
+			attrs: map[string]interface{}{
+				"service_account_token": "token-attr",
+				"address":               "test.1password.com",
+				"email":                 "test@slok.dev",
+				"secret_key":            "secret-key",
+				"password":              "password",
+			},
+			expCalls: []string{"token-attr|whoami --format json"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Use the op backend with the fake op.
+			op := onepasswordclitest.NewOpBinary(t, "2.30.0")
+			t.Setenv(provider.EnvVarOpFakeStoragePath, "")
+			t.Setenv("OP_SCIM_URL", "")
+			t.Setenv("TFC_RUN_ID", "")
+			t.Setenv("OP_SERVICE_ACCOUNT_TOKEN", test.envToken)
+			attrs := map[string]interface{}{"op_cli_path": op.Path}
+			for k, v := range test.attrs {
+				attrs[k] = v
+			}
+
+			p := provider.New()
+			req := fwprovider.ConfigureRequest{Config: newTestProviderConfig(t, p, attrs)}
+			resp := &fwprovider.ConfigureResponse{}
+			p.Configure(context.TODO(), req, resp)
+			defer func() { _ = onepasswordcli.Cleanup(context.TODO()) }()
+
+			if test.expErr != "" {
+				require.True(resp.Diagnostics.HasError())
+				assert.Contains(resp.Diagnostics.Errors()[0].Detail(), test.expErr)
+				assert.Empty(op.Calls(t), "op should not be executed")
+				return
+			}
+			require.False(resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			// The credentials are not used (no account add nor signin).
+			gotCalls := []string{}
+			for _, c := range op.Calls(t) {
+				gotCalls = append(gotCalls, c.ServiceAccountToken+"|"+strings.Join(c.Args, " "))
+			}
+			assert.Equal(test.expCalls, gotCalls)
+		})
+	}
+}
+
+func TestProviderConfigureOpCliSettings(t *testing.T) {
+	tests := map[string]struct {
+		attrs  map[string]interface{}
+		expErr string
+	}{
+		"Having valid op cli settings, should configure the provider.": {
+			attrs: map[string]interface{}{
+				"max_concurrent_op_commands": int64(2),
+				"max_retries":                int64(3),
+				"retry_max_wait":             "10s",
+			},
+		},
+
+		"Having invalid max concurrent op commands, should fail before executing op.": {
+			attrs:  map[string]interface{}{"max_concurrent_op_commands": int64(0)},
+			expErr: "max concurrent op commands must be at least 1",
+		},
+
+		"Having invalid max retries, should fail before executing op.": {
+			attrs:  map[string]interface{}{"max_retries": int64(-1)},
+			expErr: "max retries can't be negative",
+		},
+
+		"Having invalid retry max wait, should fail before executing op.": {
+			attrs:  map[string]interface{}{"retry_max_wait": "10 seconds"},
+			expErr: "invalid duration",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Use the op backend with the fake op.
+			op := onepasswordclitest.NewOpBinary(t, "2.30.0")
+			t.Setenv(provider.EnvVarOpFakeStoragePath, "")
+			t.Setenv("OP_SCIM_URL", "")
+			t.Setenv("TFC_RUN_ID", "")
+			t.Setenv("OP_SERVICE_ACCOUNT_TOKEN", "token-env")
+			attrs := map[string]interface{}{"op_cli_path": op.Path}
+			for k, v := range test.attrs {
+				attrs[k] = v
+			}
+
+			p := provider.New()
+			req := fwprovider.ConfigureRequest{Config: newTestProviderConfig(t, p, attrs)}
+			resp := &fwprovider.ConfigureResponse{}
+			p.Configure(context.TODO(), req, resp)
+			defer func() { _ = onepasswordcli.Cleanup(context.TODO()) }()
+
+			if test.expErr != "" {
+				require.True(resp.Diagnostics.HasError())
+				assert.Contains(resp.Diagnostics.Errors()[0].Detail(), test.expErr)
+				assert.Empty(op.Calls(t), "op should not be executed")
+				return
+			}
+			require.False(resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
@@ -2,17 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
@@ -27,7 +29,8 @@ func NewGroupResource() resource.Resource {
 }
 
 type groupResource struct {
-	repo storage.Repository
+	repo                 storage.Repository
+	descriptionSupported bool
 }
 
 func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,26 +44,31 @@ Provides a Group resource.
 
 A 1password group is like a team that can contain people and can be used to give access to vaults as a
 group of users.
+
+Groups can be protected from being deleted with ` + "`deletion_protection`" + `.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
 			"name": schema.StringAttribute{
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 				Required:    true,
-				Description: "The name of the group.",
+				Description: "The name of the group, renaming it will not recreate the group.",
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("Managed by Terraform"),
-				Description: "The description of the group.",
+				Description: "The description of the group (ignored by the SCIM backend, SCIM groups don't have description).",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "When enabled, the group can't be deleted (by default false). It needs to be disabled and applied before deleting the group.",
 			},
 		},
 	}
@@ -73,11 +81,12 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 	}
 
 	r.repo = appServices.Repository
+	r.descriptionSupported = appServices.GroupDescriptionSupported
 }
 
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
-	var tfGroup Group
+	var tfGroup GroupResource
 	diags := req.Plan.Get(ctx, &tfGroup)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -85,7 +94,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create group.
-	g := mapTfToModelGroup(tfGroup)
+	g := mapTfToModelGroupResource(tfGroup)
 	newGroup, err := r.repo.CreateGroup(ctx, g)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating group", "Could not create group, unexpected error: "+err.Error())
@@ -93,7 +102,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Map group to tf model.
-	newTfGroup := mapModelToTfGroup(*newGroup)
+	newTfGroup := mapModelToTfGroupResource(*newGroup, tfGroup, r.descriptionSupported)
 
 	diags = resp.State.Set(ctx, newTfGroup)
 	resp.Diagnostics.Append(diags...)
@@ -104,7 +113,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Retrieve values from plan.
-	var tfGroup Group
+	var tfGroup GroupResource
 	diags := req.State.Get(ctx, &tfGroup)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -114,13 +123,18 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Get group.
 	id := tfGroup.ID.ValueString()
 	group, err := r.repo.GetGroupByID(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading group", fmt.Sprintf("Could not get group %q, unexpected error: %s", id, err.Error()))
 		return
 	}
 
 	// Map group to tf model.
-	readTfGroup := mapModelToTfGroup(*group)
+	readTfGroup := mapModelToTfGroupResource(*group, tfGroup, r.descriptionSupported)
 
 	diags = resp.State.Set(ctx, readTfGroup)
 	resp.Diagnostics.Append(diags...)
@@ -130,7 +144,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) { // Get plan values.
-	var plan Group
+	var plan GroupResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -138,7 +152,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Get current state.
-	var state Group
+	var state GroupResource
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -146,7 +160,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Use plan group as the new data and set ID from state.
-	g := mapTfToModelGroup(plan)
+	g := mapTfToModelGroupResource(plan)
 	g.ID = state.ID.ValueString()
 
 	newGroup, err := r.repo.EnsureGroup(ctx, g)
@@ -156,7 +170,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Map group to tf model.
-	readTfGroup := mapModelToTfGroup(*newGroup)
+	readTfGroup := mapModelToTfGroupResource(*newGroup, plan, r.descriptionSupported)
 
 	diags = resp.State.Set(ctx, readTfGroup)
 	resp.Diagnostics.Append(diags...)
@@ -167,15 +181,20 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from plan.
-	var tfGroup Group
+	var tfGroup GroupResource
 	diags := req.State.Get(ctx, &tfGroup)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get group.
 	id := tfGroup.ID.ValueString()
+	if tfGroup.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting group", fmt.Sprintf("Group %q has deletion protection enabled, disable it (`deletion_protection = false`) and apply before deleting the group.", id))
+		return
+	}
+
+	// Delete group.
 	err := r.repo.DeleteGroup(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting group", fmt.Sprintf("Could not delete group %q, unexpected error: %s", id, err.Error()))
@@ -189,3 +208,35 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func mapModelToTfGroupResource(g model.Group, settings GroupResource, descriptionSupported bool) GroupResource {
+	// Imported groups don't have deletion protection.
+	deletionProtection := settings.DeletionProtection
+	if deletionProtection.IsNull() || deletionProtection.IsUnknown() {
+		deletionProtection = types.BoolValue(false)
+	}
+
+	description := types.StringValue(g.Description)
+	if !descriptionSupported {
+		// The repository doesn't store the description, keep the planned or current one.
+		description = settings.Description
+		if description.IsUnknown() {
+			description = types.StringNull()
+		}
+	}
+
+	return GroupResource{
+		ID:                 types.StringValue(g.ID),
+		Name:               types.StringValue(g.Name),
+		Description:        description,
+		DeletionProtection: deletionProtection,
+	}
+}
+
+func mapTfToModelGroupResource(g GroupResource) model.Group {
+	return model.Group{
+		ID:          g.ID.ValueString(),
+		Name:        g.Name.ValueString(),
+		Description: g.Description.ValueString(),
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -23,6 +24,7 @@ var (
 	_ resource.Resource                = &groupMemberResource{}
 	_ resource.ResourceWithConfigure   = &groupMemberResource{}
 	_ resource.ResourceWithImportState = &groupMemberResource{}
+	_ resource.ResourceWithModifyPlan  = &groupMemberResource{}
 )
 
 func NewGroupMemberResource() resource.Resource {
@@ -43,6 +45,8 @@ func (r *groupMemberResource) Schema(_ context.Context, _ resource.SchemaRequest
 Provides a user and group membership.
 
 A 1password group membership will make a user part of a group with a role on that group.
+
+Guest users can't be part of groups.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -90,6 +94,34 @@ func (r *groupMemberResource) Configure(_ context.Context, req resource.Configur
 	r.repo = appServices.Repository
 }
 
+func (r *groupMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only created or updated memberships need to be validated (destroy plans and unchanged memberships
+	// are ignored), and users that don't exist yet can't be checked.
+	if r.repo == nil || req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var userID types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root("user_id"), &userID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || userID.IsUnknown() || userID.IsNull() {
+		return
+	}
+
+	user, err := r.repo.GetUserByID(ctx, userID.ValueString())
+	if errors.Is(err, storage.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading user", fmt.Sprintf("Could not get user %q, unexpected error: %s", userID.ValueString(), err.Error()))
+		return
+	}
+
+	if user.Type == model.UserTypeGuest {
+		resp.Diagnostics.AddAttributeError(path.Root("user_id"), "Invalid group member", fmt.Sprintf("User %q is a guest, guest users can't be added to groups.", userID.ValueString()))
+	}
+}
+
 func (r *groupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
 	var tfMember Member
@@ -145,6 +177,11 @@ func (r groupMemberResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	member, err := r.repo.GetMembershipByID(ctx, groupID, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading membership", fmt.Sprintf("Could not get membership %q, unexpected error: %s", id, err.Error()))
 		return
@@ -1,11 +1,13 @@
 package provider_test
 
 import (
+	"context"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
@@ -202,3 +204,136 @@ resource "onepasswordorg_group_member" "test_member" {
 		},
 	})
 }
+
+// TestAccGroupMemberDeletedOutsideTerraform will check a membership deleted outside Terraform is recreated.
+func TestAccGroupMemberDeletedOutsideTerraform(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccGroupMemberDeletedOutsideTerraform")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+resource "onepasswordorg_group_member" "test_member" {
+  group_id = "test-group-id"
+  user_id  = "test-user-id"
+  role     = "manager"
+}
+`
+	// Fake repo IDs are based on group id + user id.
+	expMember := model.Membership{
+		GroupID: "test-group-id",
+		UserID:  "test-user-id",
+		Role:    model.MembershipRoleManager,
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertGroupMemberOnFakeStorage(t, &expMember),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Remove the membership like if it was done from 1password UI.
+					err := getFakeRepository(t).DeleteMembership(context.TODO(), expMember)
+					require.NoError(t, err)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertGroupMemberOnFakeStorage(t, &expMember),
+				),
+			},
+		},
+	})
+}
+
+// TestAccGroupMemberGuestUser will check guest users can't be added to groups.
+func TestAccGroupMemberGuestUser(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccGroupMemberGuestUser")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+resource "onepasswordorg_group_member" "test_member" {
+  group_id = "test-group-id"
+  user_id  = "guest@slok.dev"
+}
+`
+	// Prepare storage.
+	repo := getFakeRepository(t)
+	_, err := repo.CreateUser(context.TODO(), model.User{Email: "guest@slok.dev", Name: "Guest user", Type: model.UserTypeGuest})
+	require.NoError(t, err)
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`guest users can't be added to groups`),
+			},
+		},
+	})
+}
+
+// TestAccGroupMemberGuestUserUpdate will check guest users memberships can't be updated.
+func TestAccGroupMemberGuestUserUpdate(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccGroupMemberGuestUserUpdate")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configMember := `
+resource "onepasswordorg_group_member" "test_member" {
+  group_id = "test-group-id"
+  user_id  = "guest@slok.dev"
+  role     = "member"
+}
+`
+	configManager := `
+resource "onepasswordorg_group_member" "test_member" {
+  group_id = "test-group-id"
+  user_id  = "guest@slok.dev"
+  role     = "manager"
+}
+`
+	// Prepare storage with a guest membership, the user was a member when added to the group.
+	repo := getFakeRepository(t)
+	_, err := repo.CreateUser(context.TODO(), model.User{Email: "guest@slok.dev", Name: "Guest user"})
+	require.NoError(t, err)
+	err = repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "test-group-id", UserID: "guest@slok.dev", Role: model.MembershipRoleMember})
+	require.NoError(t, err)
+	err = repo.DeleteUser(context.TODO(), "guest@slok.dev")
+	require.NoError(t, err)
+	_, err = repo.CreateUser(context.TODO(), model.User{Email: "guest@slok.dev", Name: "Guest user", Type: model.UserTypeGuest})
+	require.NoError(t, err)
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             configMember,
+				ResourceName:       "onepasswordorg_group_member.test_member",
+				ImportState:        true,
+				ImportStateId:      "test-group-id/guest@slok.dev",
+				ImportStatePersist: true,
+			},
+			{
+				Config:      configManager,
+				ExpectError: regexp.MustCompile(`guest users can't be added to groups`),
+			},
+		},
+	})
+}
@@ -1,11 +1,15 @@
 package provider_test
 
 import (
+	"fmt"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
@@ -158,3 +162,156 @@ resource "onepasswordorg_group" "test_group" {
 		},
 	})
 }
+
+// TestAccGroupUpdateName will check a group is renamed without recreating it.
+func TestAccGroupUpdateName(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccGroupUpdateName")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configCreate := `
+resource "onepasswordorg_group" "test_group" {
+  name        = "test-group"
+  description = "Test group"
+}
+`
+	configUpdate := `
+resource "onepasswordorg_group" "test_group" {
+  name        = "test-group-modified"
+  description = "Test group"
+}
+`
+
+	// Fake repo IDs are based on the creation names.
+	expGroupCreate := model.Group{
+		ID:          "test-group",
+		Name:        "test-group",
+		Description: "Test group",
+	}
+
+	expGroupUpdate := model.Group{
+		ID:          "test-group",
+		Name:        "test-group-modified",
+		Description: "Test group",
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertGroupOnFakeStorage(t, &expGroupCreate),
+				),
+			},
+			{
+				Config: configUpdate,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("onepasswordorg_group.test_group", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertGroupOnFakeStorage(t, &expGroupUpdate),
+					resource.TestCheckResourceAttr("onepasswordorg_group.test_group", "id", "test-group"),
+					resource.TestCheckResourceAttr("onepasswordorg_group.test_group", "name", "test-group-modified"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccGroupDeletionProtection will check a group with deletion protection can't be deleted.
+func TestAccGroupDeletionProtection(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccGroupDeletionProtection")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configProtected := `
+resource "onepasswordorg_group" "test_group" {
+  name                = "test-group"
+  deletion_protection = true
+}
+`
+	configUnprotected := `
+resource "onepasswordorg_group" "test_group" {
+  name                = "test-group"
+  deletion_protection = false
+}
+`
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertGroupDeletedOnFakeStorage(t, "test-group"),
+		Steps: []resource.TestStep{
+			{
+				Config: configProtected,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onepasswordorg_group.test_group", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      configProtected,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`has\s+deletion\s+protection\s+enabled`),
+			},
+			{
+				// Disabling the protection should allow deleting it.
+				Config: configUnprotected,
+			},
+		},
+	})
+}
+
+// TestAccGroupSCIMDefaults will check a group with the default description is created and has no diffs
+// with the SCIM backend, that ignores the group descriptions.
+func TestAccGroupSCIMDefaults(t *testing.T) {
+	// Use the SCIM backend instead of the fake storage.
+	t.Setenv(provider.EnvVarOpFakeStoragePath, "")
+	srv := newTestSCIMServer(t)
+
+	// Test tf data.
+	config := fmt.Sprintf(`
+provider "onepasswordorg" {
+  scim_url   = %q
+  scim_token = "test-token"
+}
+
+resource "onepasswordorg_group" "test" {
+  name = "test-group"
+}
+`, srv.URL)
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onepasswordorg_group.test", "id", "test-group"),
+					resource.TestCheckResourceAttr("onepasswordorg_group.test", "description", "Managed by Terraform"),
+					func(s *terraform.State) error {
+						group := srv.group("test-group")
+						assert.NotNil(t, group)
+						assert.NotContains(t, group, "description")
+						return nil
+					},
+				),
+			},
+			// Refreshing the group should keep the description without planning changes.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
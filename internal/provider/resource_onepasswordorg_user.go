@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -9,9 +10,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
@@ -19,6 +23,7 @@ var (
 	_ resource.Resource                = &userResource{}
 	_ resource.ResourceWithConfigure   = &userResource{}
 	_ resource.ResourceWithImportState = &userResource{}
+	_ resource.ResourceWithModifyPlan  = &userResource{}
 )
 
 func NewUserResource() resource.Resource {
@@ -26,7 +31,8 @@ func NewUserResource() resource.Resource {
 }
 
 type userResource struct {
-	repo storage.Repository
+	repo                 storage.Repository
+	emailUpdateSupported bool
 }
 
 func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -39,6 +45,9 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 Provides a User resource.
 
 When a 1password user resources is created, it will be invited  by email.
+
+Users can be suspended instead of deleted (e.g: offboarding while retaining their data for audit) using
+` + "`state`" + `, or using ` + "`deletion_mode`" + ` to suspend them when the resource is destroyed.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -53,13 +62,55 @@ When a 1password user resources is created, it will be invited  by email.
 			},
 			"email": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "The email of the user. The email is changed in place (the user is not recreated), if the backend doesn't support changing it, the plan will fail.",
+			},
+			"state": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(tfUserStateActive),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfUserStateActive, tfUserStateSuspended),
+				},
+				Description: "The state of the user (can be `active` or `suspended`, by default active). Invited users that have not joined yet are `active`.",
+			},
+			"deletion_mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(tfUserDeletionModeDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfUserDeletionModeDelete, tfUserDeletionModeSuspend),
+				},
+				Description: "What to do with the user when the resource is destroyed (can be `delete` or `suspend`, by default delete). When `suspend`, the user will be suspended and its data retained in 1password.",
+			},
+			"type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
-					stringvalidator.LengthAtLeast(1),
+					stringvalidator.OneOf(tfUserTypeMember, tfUserTypeGuest),
 				},
-				Description: "The email of the user.",
+				Description: "The type of the user (can be `member` or `guest`, by default member). Changing the type will recreate the user. Users with an unknown type on 1password will be `unknown`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the user on 1password (`active`, `suspended`, `pending` or `unknown`), `pending` users are being transferred to another account.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The creation date of the user in RFC3339 format.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last update date of the user in RFC3339 format.",
 			},
 		},
 	}
@@ -72,11 +123,33 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 	}
 
 	r.repo = appServices.Repository
+	r.emailUpdateSupported = appServices.UserEmailUpdateSupported
+}
+
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only updates can change the email.
+	if r.repo == nil || r.emailUpdateSupported || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planEmail, stateEmail types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("email"), &planEmail)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("email"), &stateEmail)...)
+	if resp.Diagnostics.HasError() || planEmail.IsUnknown() {
+		return
+	}
+
+	// Don't replace the user silently, recreating a user deletes all its data.
+	if !planEmail.Equal(stateEmail) {
+		resp.Diagnostics.AddAttributeError(path.Root("email"), "Unsupported email change",
+			fmt.Sprintf("The email of the user can't be changed from %q to %q with the op CLI, the user needs to change it from their 1password account. "+
+				"If you want to replace the user (this will delete all the user data), delete the resource and create it again.", stateEmail.ValueString(), planEmail.ValueString()))
+	}
 }
 
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
-	var tfUser User
+	var tfUser UserResource
 	diags := req.Plan.Get(ctx, &tfUser)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -84,15 +157,25 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Create user.
-	u := mapTfToModelUser(tfUser)
+	u := mapTfToModelUserResource(tfUser)
 	newUser, err := r.repo.CreateUser(ctx, u)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating user", "Could not create user, unexpected error: "+err.Error())
 		return
 	}
 
+	// New users are active, suspend them if required.
+	if u.State == model.UserStateSuspended {
+		err = r.repo.SuspendUser(ctx, newUser.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating user", fmt.Sprintf("Could not suspend user %q, unexpected error: %s", newUser.ID, err.Error()))
+			return
+		}
+		newUser.State = model.UserStateSuspended
+	}
+
 	// Map user to tf model.
-	newTfUser := mapModelToTfUser(*newUser)
+	newTfUser := mapModelToTfUserResource(*newUser, tfUser.DeletionMode)
 
 	diags = resp.State.Set(ctx, newTfUser)
 	resp.Diagnostics.Append(diags...)
@@ -103,7 +186,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Retrieve values from plan.
-	var tfUser User
+	var tfUser UserResource
 	diags := req.State.Get(ctx, &tfUser)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -113,13 +196,18 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Get user.
 	id := tfUser.ID.ValueString()
 	user, err := r.repo.GetUserByID(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading user", fmt.Sprintf("Could not get user %q, unexpected error: %s", id, err.Error()))
 		return
 	}
 
 	// Map user to tf model.
-	readTfUser := mapModelToTfUser(*user)
+	readTfUser := mapModelToTfUserResource(*user, tfUser.DeletionMode)
 
 	diags = resp.State.Set(ctx, readTfUser)
 	resp.Diagnostics.Append(diags...)
@@ -130,7 +218,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Get plan values.
-	var plan User
+	var plan UserResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -138,7 +226,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Get current state.
-	var state User
+	var state UserResource
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -146,7 +234,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Use plan user as the new data and set ID from state.
-	u := mapTfToModelUser(plan)
+	u := mapTfToModelUserResource(plan)
 	u.ID = state.ID.ValueString()
 
 	newUser, err := r.repo.EnsureUser(ctx, u)
@@ -155,8 +243,25 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Change the user state if required.
+	if !plan.State.Equal(state.State) {
+		switch u.State {
+		case model.UserStateSuspended:
+			err = r.repo.SuspendUser(ctx, u.ID)
+		default:
+			err = r.repo.ReactivateUser(ctx, u.ID)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating user", fmt.Sprintf("Could not change user %q state, unexpected error: %s", u.ID, err.Error()))
+			return
+		}
+
+		// Only set the state when changed, otherwise we would replace the real state (e.g: pending).
+		newUser.State = u.State
+	}
+
 	// Map user to tf model.
-	readTfUser := mapModelToTfUser(*newUser)
+	readTfUser := mapModelToTfUserResource(*newUser, plan.DeletionMode)
 
 	diags = resp.State.Set(ctx, readTfUser)
 	resp.Diagnostics.Append(diags...)
@@ -167,15 +272,29 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from plan.
-	var tfUser User
+	var tfUser UserResource
 	diags := req.State.Get(ctx, &tfUser)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete user.
+	// Suspend the user instead of deleting if required.
 	id := tfUser.ID.ValueString()
+	if tfUser.DeletionMode.ValueString() == tfUserDeletionModeSuspend {
+		if tfUser.State.ValueString() != tfUserStateSuspended {
+			err := r.repo.SuspendUser(ctx, id)
+			if err != nil {
+				resp.Diagnostics.AddError("Error deleting user", fmt.Sprintf("Could not suspend user %q, unexpected error: %s", id, err.Error()))
+				return
+			}
+		}
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Delete user.
 	err := r.repo.DeleteUser(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting user", fmt.Sprintf("Could not delete user %q, unexpected error: %s", id, err.Error()))
@@ -189,3 +308,56 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+const (
+	tfUserStateActive    = "active"
+	tfUserStateSuspended = "suspended"
+
+	tfUserDeletionModeDelete  = "delete"
+	tfUserDeletionModeSuspend = "suspend"
+)
+
+func mapModelToTfUserResource(u model.User, deletionMode types.String) UserResource {
+	// Only suspended users are not active, the rest (e.g: invited) will be active eventually.
+	state := tfUserStateActive
+	if u.State == model.UserStateSuspended {
+		state = tfUserStateSuspended
+	}
+
+	// Imported users don't have deletion mode.
+	if deletionMode.IsNull() || deletionMode.IsUnknown() {
+		deletionMode = types.StringValue(tfUserDeletionModeDelete)
+	}
+
+	return UserResource{
+		ID:           types.StringValue(u.ID),
+		Email:        types.StringValue(u.Email),
+		Name:         types.StringValue(u.Name),
+		State:        types.StringValue(state),
+		DeletionMode: deletionMode,
+		Type:         types.StringValue(mapModelToTfUserType(u.Type)),
+		Status:       types.StringValue(mapModelToTfUserStatus(u.State)),
+		CreatedAt:    mapModelToTfTime(u.CreatedAt),
+		UpdatedAt:    mapModelToTfTime(u.UpdatedAt),
+	}
+}
+
+func mapTfToModelUserResource(u UserResource) model.User {
+	state := model.UserStateActive
+	if u.State.ValueString() == tfUserStateSuspended {
+		state = model.UserStateSuspended
+	}
+
+	userType := model.UserTypeMember
+	if u.Type.ValueString() == tfUserTypeGuest {
+		userType = model.UserTypeGuest
+	}
+
+	return model.User{
+		ID:    u.ID.ValueString(),
+		Email: u.Email.ValueString(),
+		Name:  u.Name.ValueString(),
+		Type:  userType,
+		State: state,
+	}
+}
@@ -1,11 +1,14 @@
 package provider_test
 
 import (
+	"context"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
@@ -32,6 +35,33 @@ resource "onepasswordorg_user" "test_user" {
 			},
 		},
 
+		"A guest user configuration should execute correctly.": {
+			config: `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+  type  = "guest"
+}
+`,
+			expUser: model.User{
+				ID:    "testuser@test.test",
+				Name:  "Test user",
+				Email: "testuser@test.test",
+				Type:  model.UserTypeGuest,
+			},
+		},
+
+		"An invalid type should fail.": {
+			config: `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+  type  = "owner"
+}
+`,
+			expErr: regexp.MustCompile(`Attribute type value must be one of`),
+		},
+
 		"A non set name should fail.": {
 			config: `
 resource "onepasswordorg_user" "test_user" {
@@ -81,11 +111,18 @@ resource "onepasswordorg_user" "test_user" {
 			// Prepare non error checks.
 			var checks resource.TestCheckFunc
 			if test.expErr == nil {
+				expType := "member"
+				if test.expUser.Type == model.UserTypeGuest {
+					expType = "guest"
+				}
 				checks = resource.ComposeAggregateTestCheckFunc(
 					assertUserOnFakeStorage(t, &test.expUser),
 					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "id", test.expUser.ID),
 					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "name", test.expUser.Name),
 					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "email", test.expUser.Email),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "type", expType),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "status", "active"),
+					resource.TestCheckResourceAttrSet("onepasswordorg_user.test_user", "created_at"),
 				)
 			}
 
@@ -163,3 +200,230 @@ resource "onepasswordorg_user" "test_user" {
 		},
 	})
 }
+
+// TestAccUserUpdateEmail will check a user email is updated without recreating the user.
+func TestAccUserUpdateEmail(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccUserUpdateEmail")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configCreate := `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+}
+`
+	configUpdate := `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser-modified@test.test"
+}
+`
+
+	// Fake repo IDs are based on the creation emails.
+	expUserCreate := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+	}
+
+	expUserUpdate := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser-modified@test.test",
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertUserDeletedOnFakeStorage(t, "testuser@test.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUserCreate),
+				),
+			},
+			{
+				Config: configUpdate,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("onepasswordorg_user.test_user", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUserUpdate),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "id", "testuser@test.test"),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "email", "testuser-modified@test.test"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccUserDeletedOutsideTerraform will check a user deleted outside Terraform is recreated.
+func TestAccUserDeletedOutsideTerraform(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccUserDeletedOutsideTerraform")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+}
+`
+
+	// Fake repo IDs are based on emails.
+	expUser := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUser),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Delete the user like if it was done from 1password UI.
+					err := getFakeRepository(t).DeleteUser(context.TODO(), expUser.ID)
+					require.NoError(t, err)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUser),
+				),
+			},
+		},
+	})
+}
+
+// TestAccUserUpdateState will check a user can be suspended and reactivated.
+func TestAccUserUpdateState(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccUserUpdateState")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configActive := `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+}
+`
+	configSuspended := `
+resource "onepasswordorg_user" "test_user" {
+  name  = "Test user"
+  email = "testuser@test.test"
+  state = "suspended"
+}
+`
+
+	// Fake repo IDs are based on emails.
+	expUserActive := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+		State: model.UserStateActive,
+	}
+
+	expUserSuspended := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+		State: model.UserStateSuspended,
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertUserDeletedOnFakeStorage(t, expUserActive.ID),
+		Steps: []resource.TestStep{
+			{
+				Config: configActive,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUserActive),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "state", "active"),
+				),
+			},
+			{
+				Config: configSuspended,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUserSuspended),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "state", "suspended"),
+				),
+			},
+			{
+				Config: configActive,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUserActive),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "state", "active"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccUserDeletionModeSuspend will check a user is suspended instead of deleted when destroyed.
+func TestAccUserDeletionModeSuspend(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccUserDeletionModeSuspend")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+resource "onepasswordorg_user" "test_user" {
+  name          = "Test user"
+  email         = "testuser@test.test"
+  deletion_mode = "suspend"
+}
+`
+
+	// Fake repo IDs are based on emails.
+	expUser := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+		State: model.UserStateActive,
+	}
+
+	expUserDestroyed := model.User{
+		ID:    "testuser@test.test",
+		Name:  "Test user",
+		Email: "testuser@test.test",
+		State: model.UserStateSuspended,
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertUserOnFakeStorage(t, &expUserDestroyed),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertUserOnFakeStorage(t, &expUser),
+					resource.TestCheckResourceAttr("onepasswordorg_user.test_user", "deletion_mode", "suspend"),
+				),
+			},
+		},
+	})
+}
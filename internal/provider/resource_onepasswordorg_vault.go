@@ -2,17 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
@@ -20,6 +24,7 @@ var (
 	_ resource.Resource                = &vaultResource{}
 	_ resource.ResourceWithConfigure   = &vaultResource{}
 	_ resource.ResourceWithImportState = &vaultResource{}
+	_ resource.ResourceWithModifyPlan  = &vaultResource{}
 )
 
 func NewVaultResource() resource.Resource {
@@ -27,7 +32,8 @@ func NewVaultResource() resource.Resource {
 }
 
 type vaultResource struct {
-	repo storage.Repository
+	repo                  storage.Repository
+	settingsReadSupported bool
 }
 
 func (r *vaultResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -38,6 +44,9 @@ func (r *vaultResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	resp.Schema = schema.Schema{
 		Description: `
 Provides a vault resource.
+
+Vaults can be protected from being deleted with ` + "`deletion_protection`" + `, or only when they have items
+with ` + "`prevent_destroy_if_not_empty`" + `.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -45,13 +54,10 @@ Provides a vault resource.
 			},
 			"name": schema.StringAttribute{
 				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
-				Description: "The name of the vault.",
+				Description: "The name of the vault, renaming it will not recreate the vault.",
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
@@ -59,6 +65,35 @@ Provides a vault resource.
 				Default:     stringdefault.StaticString("Managed by Terraform"),
 				Description: "The description of the vault.",
 			},
+			"icon": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfVaultIcons...),
+				},
+				Description: "The icon of the vault (e.g: `vault-door`, `gears`, `wrench`...), if not set, 1password will set the default one.",
+			},
+			"allow_admins_to_manage": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "If the administrators can manage the vault (by default true), it can only be set when creating the vault. Imported vaults and vaults created with previous provider versions are considered `true`.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "When enabled, the vault can't be deleted (by default false). It needs to be disabled and applied before deleting the vault.",
+			},
+			"prevent_destroy_if_not_empty": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "When enabled, the vault can't be deleted while it has items (by default false).",
+			},
 		},
 	}
 }
@@ -70,11 +105,32 @@ func (r *vaultResource) Configure(_ context.Context, req resource.ConfigureReque
 	}
 
 	r.repo = appServices.Repository
+	r.settingsReadSupported = appServices.VaultSettingsReadSupported
+}
+
+func (r *vaultResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only existing vaults updates need to be validated.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_admins_to_manage"), &plan)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("allow_admins_to_manage"), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.IsNull() || state.IsUnknown() || plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root("allow_admins_to_manage"), "Invalid vault update", "`allow_admins_to_manage` can only be set when creating the vault, op can't change it on existing vaults.")
 }
 
 func (r *vaultResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
-	var tfVault Vault
+	var tfVault VaultResource
 	diags := req.Plan.Get(ctx, &tfVault)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -82,7 +138,7 @@ func (r *vaultResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create vault.
-	v := mapTfToModelVault(tfVault)
+	v := mapTfToModelVaultResource(tfVault)
 	newVault, err := r.repo.CreateVault(ctx, v)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating vault", "Could not create vault, unexpected error: "+err.Error())
@@ -90,7 +146,7 @@ func (r *vaultResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Map to tf model.
-	newTfVault := mapModelToTfVault(*newVault)
+	newTfVault := mapModelToTfVaultResource(*newVault, tfVault, r.settingsReadSupported)
 
 	diags = resp.State.Set(ctx, newTfVault)
 	resp.Diagnostics.Append(diags...)
@@ -101,7 +157,7 @@ func (r *vaultResource) Create(ctx context.Context, req resource.CreateRequest,
 
 func (r *vaultResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Retrieve values from plan.
-	var tfVault Vault
+	var tfVault VaultResource
 	diags := req.State.Get(ctx, &tfVault)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -111,13 +167,30 @@ func (r *vaultResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Get resource.
 	id := tfVault.ID.ValueString()
 	vault, err := r.repo.GetVaultByID(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading vault", fmt.Sprintf("Could not get vault %q, unexpected error: %s", id, err.Error()))
 		return
 	}
 
+	// Vaults created with previous provider versions (or imported) don't have the settings on the
+	// state, if the repository doesn't return them, use the ones 1password sets by default, otherwise
+	// these vaults would have a diff on every plan.
+	if !r.settingsReadSupported {
+		if tfVault.Icon.IsNull() {
+			tfVault.Icon = types.StringValue("")
+		}
+		if tfVault.AllowAdminsToManage.IsNull() {
+			tfVault.AllowAdminsToManage = types.BoolValue(true)
+		}
+	}
+
 	// Map resource to tf model.
-	readTfVault := mapModelToTfVault(*vault)
+	readTfVault := mapModelToTfVaultResource(*vault, tfVault, r.settingsReadSupported)
 
 	diags = resp.State.Set(ctx, readTfVault)
 	resp.Diagnostics.Append(diags...)
@@ -128,7 +201,7 @@ func (r *vaultResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *vaultResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Get plan values.
-	var plan Vault
+	var plan VaultResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -136,7 +209,7 @@ func (r *vaultResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Get current state.
-	var state Vault
+	var state VaultResource
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -144,7 +217,7 @@ func (r *vaultResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Use plan group as the new data and set ID from state.
-	v := mapTfToModelVault(plan)
+	v := mapTfToModelVaultResource(plan)
 	v.ID = state.ID.ValueString()
 
 	newVault, err := r.repo.EnsureVault(ctx, v)
@@ -154,7 +227,7 @@ func (r *vaultResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Map vault to tf model.
-	readTfVault := mapModelToTfVault(*newVault)
+	readTfVault := mapModelToTfVaultResource(*newVault, plan, r.settingsReadSupported)
 
 	diags = resp.State.Set(ctx, readTfVault)
 	resp.Diagnostics.Append(diags...)
@@ -165,15 +238,33 @@ func (r *vaultResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 func (r *vaultResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from plan.
-	var tfVault Vault
+	var tfVault VaultResource
 	diags := req.State.Get(ctx, &tfVault)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete resource.
 	id := tfVault.ID.ValueString()
+	if tfVault.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting vault", fmt.Sprintf("Vault %q has deletion protection enabled, disable it (`deletion_protection = false`) and apply before deleting the vault.", id))
+		return
+	}
+
+	if tfVault.PreventDestroyIfNotEmpty.ValueBool() {
+		items, err := r.repo.CountVaultItems(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting vault", fmt.Sprintf("Could not count vault %q items, unexpected error: %s", id, err.Error()))
+			return
+		}
+
+		if items > 0 {
+			resp.Diagnostics.AddError("Error deleting vault", fmt.Sprintf("Vault %q has %d items and `prevent_destroy_if_not_empty` is enabled, remove the items or disable it and apply before deleting the vault.", id, items))
+			return
+		}
+	}
+
+	// Delete resource.
 	err := r.repo.DeleteVault(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting vault", fmt.Sprintf("Could not delete vault %q, unexpected error: %s", id, err.Error()))
@@ -188,6 +279,63 @@ func (r *vaultResource) ImportState(ctx context.Context, req resource.ImportStat
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func mapModelToTfVaultResource(v model.Vault, settings VaultResource, settingsReadSupported bool) VaultResource {
+	// Imported vaults don't have the resource settings.
+	deletionProtection := settings.DeletionProtection
+	if deletionProtection.IsNull() || deletionProtection.IsUnknown() {
+		deletionProtection = types.BoolValue(false)
+	}
+
+	preventDestroyIfNotEmpty := settings.PreventDestroyIfNotEmpty
+	if preventDestroyIfNotEmpty.IsNull() || preventDestroyIfNotEmpty.IsUnknown() {
+		preventDestroyIfNotEmpty = types.BoolValue(false)
+	}
+
+	icon := types.StringValue(v.Icon)
+	allowAdminsToManage := types.BoolValue(v.AllowAdminsToManage)
+	if !settingsReadSupported {
+		// The repository doesn't return the vault settings, keep the planned or current ones.
+		if !settings.Icon.IsNull() && !settings.Icon.IsUnknown() {
+			icon = settings.Icon
+		}
+
+		allowAdminsToManage = settings.AllowAdminsToManage
+		if allowAdminsToManage.IsUnknown() {
+			allowAdminsToManage = types.BoolNull()
+		}
+	}
+
+	return VaultResource{
+		ID:                       types.StringValue(v.ID),
+		Name:                     types.StringValue(v.Name),
+		Description:              types.StringValue(v.Description),
+		Icon:                     icon,
+		AllowAdminsToManage:      allowAdminsToManage,
+		DeletionProtection:       deletionProtection,
+		PreventDestroyIfNotEmpty: preventDestroyIfNotEmpty,
+	}
+}
+
+func mapTfToModelVaultResource(v VaultResource) model.Vault {
+	return model.Vault{
+		ID:                  v.ID.ValueString(),
+		Name:                v.Name.ValueString(),
+		Description:         v.Description.ValueString(),
+		Icon:                v.Icon.ValueString(),
+		AllowAdminsToManage: v.AllowAdminsToManage.ValueBool(),
+	}
+}
+
+// tfVaultIcons are the vault icons supported by op.
+var tfVaultIcons = []string{
+	"airplane", "application", "art-supplies", "bankers-box", "brown-briefcase", "brown-gate", "buildings",
+	"cabin", "castle", "circle-of-dots", "coffee", "color-wheel", "curtained-window", "document", "doughnut",
+	"fence", "galaxy", "gears", "globe", "green-backpack", "green-gem", "handshake", "heart-with-monitor",
+	"house", "id-card", "jet", "large-ship", "luggage", "plant", "porthole", "puzzle", "rainbow", "record",
+	"round-door", "sandals", "scales", "screwdriver", "shop", "tall-window", "treasure-chest", "vault-door",
+	"vehicle", "wallet", "wrench",
+}
+
 func getAppServicesFromResourceRequest(req *resource.ConfigureRequest) *providerAppServices {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(providerAppServices); ok {
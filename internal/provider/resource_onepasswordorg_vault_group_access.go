@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -19,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &vaultUserAccessResource{}
-	_ resource.ResourceWithConfigure   = &vaultUserAccessResource{}
-	_ resource.ResourceWithImportState = &vaultUserAccessResource{}
+	_ resource.Resource                   = &vaultGroupAccessResource{}
+	_ resource.ResourceWithConfigure      = &vaultGroupAccessResource{}
+	_ resource.ResourceWithImportState    = &vaultGroupAccessResource{}
+	_ resource.ResourceWithValidateConfig = &vaultGroupAccessResource{}
+	_ resource.ResourceWithModifyPlan     = &vaultGroupAccessResource{}
 )
 
 func NewVaultGroupAccessResource() resource.Resource {
@@ -29,7 +32,8 @@ func NewVaultGroupAccessResource() resource.Resource {
 }
 
 type vaultGroupAccessResource struct {
-	repo storage.Repository
+	repo        storage.Repository
+	accountType string
 }
 
 func (r *vaultGroupAccessResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +69,7 @@ Provides vault access for a group.
 				},
 				Description: "The group ID.",
 			},
+			"preset":      presetAttribute,
 			"permissions": permissionsAttribute,
 		},
 	}
@@ -77,6 +82,15 @@ func (r *vaultGroupAccessResource) Configure(_ context.Context, req resource.Con
 	}
 
 	r.repo = appServices.Repository
+	r.accountType = appServices.AccountType
+}
+
+func (r *vaultGroupAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateAccessPresetConfig(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r *vaultGroupAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanAccessPreset(ctx, r.accountType, req, resp)
 }
 
 func (r vaultGroupAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,6 +122,9 @@ func (r vaultGroupAccessResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// Preset is not part of the access, keep the planned one.
+	newTfAccess.Preset = tfvga.Preset
+
 	// Set on state.
 	diags = resp.State.Set(ctx, newTfAccess)
 	resp.Diagnostics.Append(diags...)
@@ -134,6 +151,11 @@ func (r vaultGroupAccessResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	access, err := r.repo.GetVaultGroupAccessByID(ctx, vaultID, groupID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading access", fmt.Sprintf("Could not get access %q, unexpected error: %s", id, err.Error()))
 		return
@@ -146,6 +168,9 @@ func (r vaultGroupAccessResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	// Preset is not part of the access, keep the one from the state.
+	readTfVaultGroupAccess.Preset = tfVaultGroupAccess.Preset
+
 	diags = resp.State.Set(ctx, readTfVaultGroupAccess)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -192,6 +217,9 @@ func (r vaultGroupAccessResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	// Preset is not part of the access, keep the planned one.
+	newTfAccess.Preset = plan.Preset
+
 	// Set on state.
 	diags = resp.State.Set(ctx, newTfAccess)
 	resp.Diagnostics.Append(diags...)
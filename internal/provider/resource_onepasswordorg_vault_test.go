@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
@@ -26,9 +27,11 @@ resource "onepasswordorg_vault" "test" {
 }
 `,
 			expVault: model.Vault{
-				ID:          "test-vault",
-				Name:        "test-vault",
-				Description: "Test vault",
+				ID:                  "test-vault",
+				Name:                "test-vault",
+				Description:         "Test vault",
+				Icon:                "vault-door",
+				AllowAdminsToManage: true,
 			},
 		},
 
@@ -40,12 +43,42 @@ resource "onepasswordorg_vault" "test" {
 }
 		`,
 			expVault: model.Vault{
-				ID:          "test-vault",
-				Name:        "test-vault",
-				Description: "Managed by Terraform",
+				ID:                  "test-vault",
+				Name:                "test-vault",
+				Description:         "Managed by Terraform",
+				Icon:                "vault-door",
+				AllowAdminsToManage: true,
 			},
 		},
 
+		"A configuration with icon and admins management should execute correctly.": {
+			config: `
+resource "onepasswordorg_vault" "test" {
+  name                   = "test-vault"
+  description            = "Test vault"
+  icon                   = "gears"
+  allow_admins_to_manage = false
+}
+`,
+			expVault: model.Vault{
+				ID:                  "test-vault",
+				Name:                "test-vault",
+				Description:         "Test vault",
+				Icon:                "gears",
+				AllowAdminsToManage: false,
+			},
+		},
+
+		"An invalid icon should fail.": {
+			config: `
+resource "onepasswordorg_vault" "test" {
+  name = "test-vault"
+  icon = "invalid-icon"
+}
+`,
+			expErr: regexp.MustCompile(`Attribute icon value must be one of`),
+		},
+
 		"A non set name should fail.": {
 			config: `
 resource "onepasswordorg_vault" "test" {
@@ -125,15 +158,19 @@ resource "onepasswordorg_vault" "test" {
 
 	// Fake repo IDs are based on emails.
 	expVaultCreate := model.Vault{
-		ID:          "test-vault",
-		Name:        "test-vault",
-		Description: "Test vault",
+		ID:                  "test-vault",
+		Name:                "test-vault",
+		Description:         "Test vault",
+		Icon:                "vault-door",
+		AllowAdminsToManage: true,
 	}
 
 	expVaultUpdate := model.Vault{
-		ID:          "test-vault",
-		Name:        "test-vault",
-		Description: "Test vault modified",
+		ID:                  "test-vault",
+		Name:                "test-vault",
+		Description:         "Test vault modified",
+		Icon:                "vault-door",
+		AllowAdminsToManage: true,
 	}
 
 	// Execute test.
@@ -159,3 +196,199 @@ resource "onepasswordorg_vault" "test" {
 		},
 	})
 }
+
+// TestAccVaultUpdateAllowAdminsToManage will check changing if the admins can manage an existing vault fails.
+func TestAccVaultUpdateAllowAdminsToManage(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccVaultUpdateAllowAdminsToManage")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configCreate := `
+resource "onepasswordorg_vault" "test" {
+  name = "test-vault"
+}
+`
+	configUpdate := `
+resource "onepasswordorg_vault" "test" {
+  name                   = "test-vault"
+  allow_admins_to_manage = false
+}
+`
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onepasswordorg_vault.test", "allow_admins_to_manage", "true"),
+				),
+			},
+			{
+				Config:      configUpdate,
+				ExpectError: regexp.MustCompile("can only be set when creating the vault"),
+			},
+		},
+	})
+}
+
+// TestAccVaultUpdateName will check a vault is renamed without recreating it.
+func TestAccVaultUpdateName(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccVaultUpdateName")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configCreate := `
+resource "onepasswordorg_vault" "test" {
+  name        = "test-vault"
+  description = "Test vault"
+}
+`
+	configUpdate := `
+resource "onepasswordorg_vault" "test" {
+  name        = "test-vault-modified"
+  description = "Test vault"
+}
+`
+
+	// Fake repo IDs are based on the creation names.
+	expVaultCreate := model.Vault{
+		ID:                  "test-vault",
+		Name:                "test-vault",
+		Description:         "Test vault",
+		Icon:                "vault-door",
+		AllowAdminsToManage: true,
+	}
+
+	expVaultUpdate := model.Vault{
+		ID:                  "test-vault",
+		Name:                "test-vault-modified",
+		Description:         "Test vault",
+		Icon:                "vault-door",
+		AllowAdminsToManage: true,
+	}
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertVaultOnFakeStorage(t, &expVaultCreate),
+				),
+			},
+			{
+				Config: configUpdate,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("onepasswordorg_vault.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					assertVaultOnFakeStorage(t, &expVaultUpdate),
+					resource.TestCheckResourceAttr("onepasswordorg_vault.test", "id", "test-vault"),
+					resource.TestCheckResourceAttr("onepasswordorg_vault.test", "name", "test-vault-modified"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccVaultDeletionProtection will check a vault with deletion protection can't be deleted.
+func TestAccVaultDeletionProtection(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccVaultDeletionProtection")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	configProtected := `
+resource "onepasswordorg_vault" "test" {
+  name                = "test-vault"
+  deletion_protection = true
+}
+`
+	configUnprotected := `
+resource "onepasswordorg_vault" "test" {
+  name                = "test-vault"
+  deletion_protection = false
+}
+`
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertVaultDeletedOnFakeStorage(t, "test-vault"),
+		Steps: []resource.TestStep{
+			{
+				Config: configProtected,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("onepasswordorg_vault.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      configProtected,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`has\s+deletion\s+protection\s+enabled`),
+			},
+			{
+				// Disabling the protection should allow deleting it.
+				Config: configUnprotected,
+			},
+		},
+	})
+}
+
+// TestAccVaultPreventDestroyIfNotEmpty will check a vault with items can't be deleted when
+// prevent_destroy_if_not_empty is enabled.
+func TestAccVaultPreventDestroyIfNotEmpty(t *testing.T) {
+	// Prepare fake storage.
+	path, delete := getFakeRepoTmpFile("TestAccVaultPreventDestroyIfNotEmpty")
+	defer delete()
+	_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+	// Test tf data.
+	config := `
+resource "onepasswordorg_vault" "test" {
+  name                         = "test-vault"
+  prevent_destroy_if_not_empty = true
+}
+`
+
+	// Execute test.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             assertVaultDeletedOnFakeStorage(t, "test-vault"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					// Add items like if they were created from 1password.
+					setFakeVaultItems(t, "test-vault", 2)
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`has\s+2\s+items`),
+			},
+			{
+				PreConfig: func() {
+					// Without items the vault can be deleted.
+					setFakeVaultItems(t, "test-vault", 0)
+				},
+				Config: config,
+			},
+		},
+	})
+}
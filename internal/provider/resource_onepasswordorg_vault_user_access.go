@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -19,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &vaultUserAccessResource{}
-	_ resource.ResourceWithConfigure   = &vaultUserAccessResource{}
-	_ resource.ResourceWithImportState = &vaultUserAccessResource{}
+	_ resource.Resource                   = &vaultUserAccessResource{}
+	_ resource.ResourceWithConfigure      = &vaultUserAccessResource{}
+	_ resource.ResourceWithImportState    = &vaultUserAccessResource{}
+	_ resource.ResourceWithValidateConfig = &vaultUserAccessResource{}
+	_ resource.ResourceWithModifyPlan     = &vaultUserAccessResource{}
 )
 
 func NewVaultUserAccessResource() resource.Resource {
@@ -29,7 +32,8 @@ func NewVaultUserAccessResource() resource.Resource {
 }
 
 type vaultUserAccessResource struct {
-	repo storage.Repository
+	repo        storage.Repository
+	accountType string
 }
 
 func (r *vaultUserAccessResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +69,7 @@ Provides vault access for a user.
 				},
 				Description: "The user ID.",
 			},
+			"preset":      presetAttribute,
 			"permissions": permissionsAttribute,
 		},
 	}
@@ -77,6 +82,15 @@ func (r *vaultUserAccessResource) Configure(_ context.Context, req resource.Conf
 	}
 
 	r.repo = appServices.Repository
+	r.accountType = appServices.AccountType
+}
+
+func (r *vaultUserAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateAccessPresetConfig(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r *vaultUserAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanAccessPreset(ctx, r.accountType, req, resp)
 }
 
 func (r *vaultUserAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,6 +122,9 @@ func (r *vaultUserAccessResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// Preset is not part of the access, keep the planned one.
+	newTfAccess.Preset = tfvga.Preset
+
 	// Set on state.
 	diags = resp.State.Set(ctx, newTfAccess)
 	resp.Diagnostics.Append(diags...)
@@ -134,6 +151,11 @@ func (r *vaultUserAccessResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	access, err := r.repo.GetVaultUserAccessByID(ctx, vaultID, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted outside Terraform, remove it from the state so Terraform can recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading access", fmt.Sprintf("Could not get access %q, unexpected error: %s", id, err.Error()))
 		return
@@ -146,6 +168,9 @@ func (r *vaultUserAccessResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	// Preset is not part of the access, keep the one from the state.
+	readTfVaultUserAccess.Preset = tfVaultUserAccess.Preset
+
 	diags = resp.State.Set(ctx, readTfVaultUserAccess)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -192,6 +217,9 @@ func (r *vaultUserAccessResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	// Preset is not part of the access, keep the planned one.
+	newTfAccess.Preset = plan.Preset
+
 	// Set on state.
 	diags = resp.State.Set(ctx, newTfAccess)
 	resp.Diagnostics.Append(diags...)
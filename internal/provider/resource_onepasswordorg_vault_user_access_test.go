@@ -76,7 +76,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{AllowEditing: true},
+				Permissions: model.AccessPermissions{AllowViewing: true, AllowEditing: true},
 			},
 		},
 
@@ -130,7 +130,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{CreateItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, CreateItems: true},
 			},
 		},
 
@@ -148,7 +148,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{EditItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, EditItems: true},
 			},
 		},
 
@@ -166,7 +166,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{ArchiveItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, EditItems: true, ArchiveItems: true},
 			},
 		},
 
@@ -184,7 +184,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{DeleteItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, EditItems: true, DeleteItems: true},
 			},
 		},
 
@@ -202,7 +202,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{ViewAndCopyPasswords: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true},
 			},
 		},
 
@@ -220,7 +220,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{ViewItemHistory: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, ViewItemHistory: true},
 			},
 		},
 
@@ -238,7 +238,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{ImportItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, CreateItems: true, ImportItems: true},
 			},
 		},
 
@@ -256,7 +256,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{ExportItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, ViewItemHistory: true, ExportItems: true},
 			},
 		},
 
@@ -274,7 +274,7 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{CopyAndShareItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, ViewItemHistory: true, CopyAndShareItems: true},
 			},
 		},
 
@@ -292,10 +292,64 @@ resource "onepasswordorg_vault_user_access" "test" {
 			expVGA: model.VaultUserAccess{
 				VaultID:     "test-vault-id",
 				UserID:      "test-user-id",
-				Permissions: model.AccessPermissions{PrintItems: true},
+				Permissions: model.AccessPermissions{ViewItems: true, ViewAndCopyPasswords: true, ViewItemHistory: true, PrintItems: true},
 			},
 		},
 
+		"Permissions required by the enabled ones should be enabled.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id  = "test-vault-id"
+  user_id = "test-user-id" 
+  permissions = {
+	delete_items = true
+	print_items = true
+  }
+}
+`,
+			expID: "test-vault-id/test-user-id",
+			expVGA: model.VaultUserAccess{
+				VaultID: "test-vault-id",
+				UserID:  "test-user-id",
+				Permissions: model.AccessPermissions{
+					ViewItems:            true,
+					ViewAndCopyPasswords: true,
+					EditItems:            true,
+					DeleteItems:          true,
+					ViewItemHistory:      true,
+					PrintItems:           true,
+				},
+			},
+		},
+
+		"Disabling a permission required by an enabled one should fail.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id  = "test-vault-id"
+  user_id = "test-user-id" 
+  permissions = {
+	edit_items = true
+	view_and_copy_passwords = false
+  }
+}
+`,
+			expErr: regexp.MustCompile(`"edit_items"\s+permission\s+requires\s+"view_and_copy_passwords"\s+permission`),
+		},
+
+		"Mixing teams and business permissions should fail.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id  = "test-vault-id"
+  user_id = "test-user-id" 
+  permissions = {
+	allow_viewing = true
+	view_items = true
+  }
+}
+`,
+			expErr: regexp.MustCompile(`Teams\s+permissions\s+\(allow_viewing\)\s+can't\s+be\s+used\s+with\s+business\s+permissions\s+\(view_items\)`),
+		},
+
 		"Permission manage_vault check.": {
 			config: `
 resource "onepasswordorg_vault_user_access" "test" {
@@ -389,8 +443,6 @@ resource "onepasswordorg_vault_user_access" "test" {
   vault_id  = "test-vault-id"
   user_id = "test-user-id" 
   permissions = {
-	  allow_viewing = false
-	  allow_editing = true
 	  view_and_copy_passwords = true
 	  print_items = true
   }
@@ -410,8 +462,9 @@ resource "onepasswordorg_vault_user_access" "test" {
 		VaultID: "test-vault-id",
 		UserID:  "test-user-id",
 		Permissions: model.AccessPermissions{
-			AllowEditing:         true,
+			ViewItems:            true,
 			ViewAndCopyPasswords: true,
+			ViewItemHistory:      true,
 			PrintItems:           true,
 		},
 	}
@@ -436,3 +489,155 @@ resource "onepasswordorg_vault_user_access" "test" {
 		},
 	})
 }
+
+// TestAccVaultUserAccessPreset will check a vault user access preset sets the permissions based on the account type.
+func TestAccVaultUserAccessPreset(t *testing.T) {
+	tests := map[string]struct {
+		config         string
+		expPermissions model.AccessPermissions
+		expErr         *regexp.Regexp
+	}{
+		"A read only preset on a business account should set the business read only permissions.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "read_only"
+}
+`,
+			expPermissions: model.AccessPermissions{
+				ViewItems:            true,
+				ViewAndCopyPasswords: true,
+				ViewItemHistory:      true,
+			},
+		},
+
+		"An admin preset on a business account should set the business admin permissions.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "admin"
+}
+`,
+			expPermissions: model.AccessPermissions{
+				ViewItems:            true,
+				ViewAndCopyPasswords: true,
+				ViewItemHistory:      true,
+				CreateItems:          true,
+				EditItems:            true,
+				ArchiveItems:         true,
+				DeleteItems:          true,
+				ImportItems:          true,
+				ExportItems:          true,
+				CopyAndShareItems:    true,
+				PrintItems:           true,
+				ManageVault:          true,
+			},
+		},
+
+		"A read write preset on a teams account should set the teams read write permissions.": {
+			config: `
+provider "onepasswordorg" {
+  account_type = "teams"
+}
+
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "read_write"
+}
+`,
+			expPermissions: model.AccessPermissions{
+				AllowViewing: true,
+				AllowEditing: true,
+			},
+		},
+
+		"A custom preset should use the permissions.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "custom"
+  permissions = {
+	manage_vault = true
+  }
+}
+`,
+			expPermissions: model.AccessPermissions{ManageVault: true},
+		},
+
+		"A preset with permissions should fail.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "read_only"
+  permissions = {
+	manage_vault = true
+  }
+}
+`,
+			expErr: regexp.MustCompile(`permissions can't be set with "read_only" preset`),
+		},
+
+		"Without preset and permissions should fail.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+}
+`,
+			expErr: regexp.MustCompile(`permissions are required when the preset is not set`),
+		},
+
+		"An invalid preset should fail.": {
+			config: `
+resource "onepasswordorg_vault_user_access" "test" {
+  vault_id = "test-vault-id"
+  user_id = "test-user-id"
+  preset   = "owner"
+}
+`,
+			expErr: regexp.MustCompile(`Attribute preset value must be one of`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Prepare fake storage.
+			path, delete := getFakeRepoTmpFile("TestAccVaultUserAccessPreset")
+			defer delete()
+			_ = os.Setenv(provider.EnvVarOpFakeStoragePath, path)
+
+			expVUA := model.VaultUserAccess{
+				VaultID:     "test-vault-id",
+				UserID:      "test-user-id",
+				Permissions: test.expPermissions,
+			}
+
+			// Prepare non error checks.
+			var checks resource.TestCheckFunc
+			if test.expErr == nil {
+				checks = resource.ComposeAggregateTestCheckFunc(
+					assertVaultUserAccessOnFakeStorage(t, &expVUA),
+				)
+			}
+
+			// Execute test.
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				CheckDestroy:             assertVaultUserAccessDeletedOnFakeStorage(t, expVUA.VaultID, expVUA.UserID),
+				Steps: []resource.TestStep{
+					{
+						Config:      test.config,
+						Check:       checks,
+						ExpectError: test.expErr,
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+// NewRepository returns a storage.Repository that caches the group members and vault accesses
+// lists of the wrapped repository, these lists are the ones used to get a single membership or
+// vault access, so getting many of them of the same group or vault (e.g: on a plan) will only
+// list once.
+//
+// The cached lists are invalidated when the repository writes on them, and concurrent list calls
+// of the same group or vault will be deduplicated into a single one.
+func NewRepository(repo storage.Repository) storage.Repository {
+	return &repository{
+		Repository:         repo,
+		groupMembers:       newListCache[model.GroupMember](),
+		vaultGroupAccesses: newListCache[model.VaultGroupAccess](),
+		vaultUserAccesses:  newListCache[model.VaultUserAccess](),
+	}
+}
+
+type repository struct {
+	storage.Repository
+
+	groupMembers       *listCache[model.GroupMember]
+	vaultGroupAccesses *listCache[model.VaultGroupAccess]
+	vaultUserAccesses  *listCache[model.VaultUserAccess]
+}
+
+func (r *repository) EnsureUser(ctx context.Context, user model.User) (*model.User, error) {
+	u, err := r.Repository.EnsureUser(ctx, user)
+	// The group members have the user data.
+	r.groupMembers.invalidateAll()
+	return u, err
+}
+
+func (r *repository) DeleteUser(ctx context.Context, id string) error {
+	err := r.Repository.DeleteUser(ctx, id)
+	// A deleted user is removed from all the groups and vaults.
+	r.groupMembers.invalidateAll()
+	r.vaultUserAccesses.invalidateAll()
+	return err
+}
+
+func (r *repository) SuspendUser(ctx context.Context, id string) error {
+	err := r.Repository.SuspendUser(ctx, id)
+	// The group members have the user data.
+	r.groupMembers.invalidateAll()
+	return err
+}
+
+func (r *repository) ReactivateUser(ctx context.Context, id string) error {
+	err := r.Repository.ReactivateUser(ctx, id)
+	r.groupMembers.invalidateAll()
+	return err
+}
+
+func (r *repository) DeleteGroup(ctx context.Context, id string) error {
+	err := r.Repository.DeleteGroup(ctx, id)
+	// A deleted group is removed from all the vaults.
+	r.groupMembers.invalidate(id)
+	r.vaultGroupAccesses.invalidateAll()
+	return err
+}
+
+func (r *repository) DeleteVault(ctx context.Context, id string) error {
+	err := r.Repository.DeleteVault(ctx, id)
+	r.vaultGroupAccesses.invalidate(id)
+	r.vaultUserAccesses.invalidate(id)
+	return err
+}
+
+func (r *repository) EnsureMembership(ctx context.Context, membership model.Membership) error {
+	err := r.Repository.EnsureMembership(ctx, membership)
+	r.groupMembers.invalidate(membership.GroupID)
+	return err
+}
+
+func (r *repository) DeleteMembership(ctx context.Context, membership model.Membership) error {
+	err := r.Repository.DeleteMembership(ctx, membership)
+	r.groupMembers.invalidate(membership.GroupID)
+	return err
+}
+
+func (r *repository) GetMembershipByID(ctx context.Context, groupID, userID string) (*model.Membership, error) {
+	members, err := r.ListGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		if m.User.ID == userID {
+			return &model.Membership{
+				UserID:  userID,
+				GroupID: groupID,
+				Role:    m.Role,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("member %q in group %q: %w", userID, groupID, storage.ErrNotFound)
+}
+
+func (r *repository) ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error) {
+	return r.groupMembers.get(groupID, func() ([]model.GroupMember, error) {
+		return r.Repository.ListGroupMembers(ctx, groupID)
+	})
+}
+
+func (r *repository) EnsureVaultGroupAccess(ctx context.Context, groupAccess model.VaultGroupAccess) error {
+	err := r.Repository.EnsureVaultGroupAccess(ctx, groupAccess)
+	r.vaultGroupAccesses.invalidate(groupAccess.VaultID)
+	return err
+}
+
+func (r *repository) DeleteVaultGroupAccess(ctx context.Context, vaultID string, groupID string) error {
+	err := r.Repository.DeleteVaultGroupAccess(ctx, vaultID, groupID)
+	r.vaultGroupAccesses.invalidate(vaultID)
+	return err
+}
+
+func (r *repository) GetVaultGroupAccessByID(ctx context.Context, vaultID string, groupID string) (*model.VaultGroupAccess, error) {
+	accesses, err := r.ListVaultGroupAccesses(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accesses {
+		if a.GroupID == groupID {
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("group access %q in vault %q: %w", groupID, vaultID, storage.ErrNotFound)
+}
+
+func (r *repository) ListVaultGroupAccesses(ctx context.Context, vaultID string) ([]model.VaultGroupAccess, error) {
+	return r.vaultGroupAccesses.get(vaultID, func() ([]model.VaultGroupAccess, error) {
+		return r.Repository.ListVaultGroupAccesses(ctx, vaultID)
+	})
+}
+
+func (r *repository) EnsureVaultUserAccess(ctx context.Context, userAccess model.VaultUserAccess) error {
+	err := r.Repository.EnsureVaultUserAccess(ctx, userAccess)
+	r.vaultUserAccesses.invalidate(userAccess.VaultID)
+	return err
+}
+
+func (r *repository) DeleteVaultUserAccess(ctx context.Context, vaultID string, userID string) error {
+	err := r.Repository.DeleteVaultUserAccess(ctx, vaultID, userID)
+	r.vaultUserAccesses.invalidate(vaultID)
+	return err
+}
+
+func (r *repository) GetVaultUserAccessByID(ctx context.Context, vaultID string, userID string) (*model.VaultUserAccess, error) {
+	accesses, err := r.ListVaultUserAccesses(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accesses {
+		if a.UserID == userID {
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user access %q in vault %q: %w", userID, vaultID, storage.ErrNotFound)
+}
+
+func (r *repository) ListVaultUserAccesses(ctx context.Context, vaultID string) ([]model.VaultUserAccess, error) {
+	return r.vaultUserAccesses.get(vaultID, func() ([]model.VaultUserAccess, error) {
+		return r.Repository.ListVaultUserAccesses(ctx, vaultID)
+	})
+}
+
+// listCache caches lists by key, only successful lists are cached.
+type listCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string][]T
+	// generation is increased on every invalidation, this way we know if a list that was
+	// in flight while invalidating is stale and shouldn't be cached.
+	generation uint64
+	calls      singleflight.Group
+	// keys are the keys of the lists that could be in flight, singleflight doesn't expose them
+	// and we need them to forget all the in flight lists when invalidating everything.
+	keys map[string]struct{}
+}
+
+func newListCache[T any]() *listCache[T] {
+	return &listCache[T]{
+		entries: map[string][]T{},
+		keys:    map[string]struct{}{},
+	}
+}
+
+func (l *listCache[T]) get(key string, list func() ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	if !ok {
+		l.keys[key] = struct{}{}
+	}
+	l.mu.Unlock()
+	if ok {
+		return slices.Clone(entry), nil
+	}
+
+	res, err, _ := l.calls.Do(key, func() (interface{}, error) {
+		l.mu.Lock()
+		generation := l.generation
+		l.mu.Unlock()
+
+		entry, err := list()
+		if err != nil {
+			return nil, err
+		}
+
+		l.mu.Lock()
+		if generation == l.generation {
+			l.entries[key] = entry
+		}
+		l.mu.Unlock()
+
+		return entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(res.([]T)), nil
+}
+
+func (l *listCache[T]) invalidate(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.generation++
+	delete(l.entries, key)
+	l.calls.Forget(key)
+}
+
+func (l *listCache[T]) invalidateAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.generation++
+	l.entries = map[string][]T{}
+	for key := range l.keys {
+		l.calls.Forget(key)
+	}
+	l.keys = map[string]struct{}{}
+}
@@ -0,0 +1,231 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/cache"
+)
+
+// testRepository is a storage.Repository that counts the list calls, only the methods
+// used by the cache are implemented.
+type testRepository struct {
+	storage.Repository
+
+	mu        sync.Mutex
+	members   map[string][]model.GroupMember
+	listCalls map[string]int
+	listErr   error
+	// listHook is called on every list with the number of the list call.
+	listHook func(call int)
+}
+
+func newTestRepository() *testRepository {
+	return &testRepository{
+		members: map[string][]model.GroupMember{
+			"group-00": {
+				{User: model.User{ID: "user-00"}, Role: model.MembershipRoleMember},
+				{User: model.User{ID: "user-01"}, Role: model.MembershipRoleManager},
+			},
+		},
+		listCalls: map[string]int{},
+	}
+}
+
+func (t *testRepository) ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error) {
+	t.mu.Lock()
+	t.listCalls[groupID]++
+	call := t.listCalls[groupID]
+	members := slices.Clone(t.members[groupID])
+	t.mu.Unlock()
+
+	if t.listHook != nil {
+		t.listHook(call)
+	}
+
+	if t.listErr != nil {
+		return nil, t.listErr
+	}
+	return members, nil
+}
+
+func (t *testRepository) EnsureMembership(ctx context.Context, membership model.Membership) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.members[membership.GroupID] = append(t.members[membership.GroupID], model.GroupMember{
+		User: model.User{ID: membership.UserID},
+		Role: membership.Role,
+	})
+	return nil
+}
+
+func (t *testRepository) EnsureUser(ctx context.Context, user model.User) (*model.User, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, members := range t.members {
+		for i, m := range members {
+			if m.User.ID == user.ID {
+				members[i].User = user
+			}
+		}
+	}
+	return &user, nil
+}
+
+func (t *testRepository) DeleteUser(ctx context.Context, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for groupID, members := range t.members {
+		t.members[groupID] = slices.DeleteFunc(members, func(m model.GroupMember) bool { return m.User.ID == id })
+	}
+	return nil
+}
+
+func TestRepositoryGetMembershipByID(t *testing.T) {
+	tests := map[string]struct {
+		exec           func(repo storage.Repository) (*model.Membership, error)
+		listErr        error
+		expMembership  *model.Membership
+		expListCalls   int
+		expErr         bool
+		expErrNotFound bool
+	}{
+		"Getting multiple members of the same group, should list the group once.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-01")
+			},
+			expMembership: &model.Membership{GroupID: "group-00", UserID: "user-01", Role: model.MembershipRoleManager},
+			expListCalls:  1,
+		},
+
+		"Getting a member after changing the group members, should list the group again.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+				_ = repo.EnsureMembership(context.TODO(), model.Membership{GroupID: "group-00", UserID: "user-02", Role: model.MembershipRoleMember})
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-02")
+			},
+			expMembership: &model.Membership{GroupID: "group-00", UserID: "user-02", Role: model.MembershipRoleMember},
+			expListCalls:  2,
+		},
+
+		"Getting a member after updating a user, should list the group again.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+				_, _ = repo.EnsureUser(context.TODO(), model.User{ID: "user-00", Name: "User00"})
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+			},
+			expMembership: &model.Membership{GroupID: "group-00", UserID: "user-00", Role: model.MembershipRoleMember},
+			expListCalls:  2,
+		},
+
+		"Getting a member after deleting a user, should list the group again.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+				_ = repo.DeleteUser(context.TODO(), "user-00")
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+			},
+			expListCalls:   2,
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Getting a missing member, should fail with not found.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-02")
+			},
+			expListCalls:   1,
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Having an error while listing, should not cache the list.": {
+			exec: func(repo storage.Repository) (*model.Membership, error) {
+				_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+				return repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+			},
+			listErr:      fmt.Errorf("something"),
+			expListCalls: 2,
+			expErr:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			tr := newTestRepository()
+			tr.listErr = test.listErr
+			repo := cache.NewRepository(tr)
+
+			gotMembership, err := test.exec(repo)
+
+			if test.expErr {
+				require.Error(err)
+				if test.expErrNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
+			} else if assert.NoError(err) {
+				assert.Equal(test.expMembership, gotMembership)
+			}
+			assert.Equal(test.expListCalls, tr.listCalls["group-00"])
+		})
+	}
+}
+
+func TestRepositoryInvalidateAllInFlight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The first list will block until we release it, so we can invalidate while it's in flight.
+	listing := make(chan struct{})
+	release := make(chan struct{})
+	tr := newTestRepository()
+	tr.listHook = func(call int) {
+		if call == 1 {
+			close(listing)
+			<-release
+		}
+	}
+	repo := cache.NewRepository(tr)
+
+	inFlightDone := make(chan struct{})
+	go func() {
+		defer close(inFlightDone)
+		_, _ = repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+	}()
+	<-listing
+
+	// Deleting a user invalidates all the groups, the in flight list is stale now.
+	require.NoError(repo.DeleteUser(context.TODO(), "user-00"))
+
+	errC := make(chan error, 1)
+	go func() {
+		_, err := repo.GetMembershipByID(context.TODO(), "group-00", "user-00")
+		errC <- err
+	}()
+
+	select {
+	case err := <-errC:
+		assert.ErrorIs(err, storage.ErrNotFound)
+	case <-time.After(5 * time.Second):
+		assert.Fail("the get joined the stale in flight list")
+	}
+
+	close(release)
+	<-inFlightDone
+	assert.Equal(2, tr.listCalls["group-00"])
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
@@ -17,11 +19,15 @@ type repository struct {
 	groupsByID           map[string]model.Group
 	membershipByID       map[string]model.Membership
 	vaultsByID           map[string]model.Vault
+	vaultItemsByID       map[string]int
 	vaultGroupAccessByID map[string]model.VaultGroupAccess
 	vaultUserAccessByID  map[string]model.VaultUserAccess
 	storageMu            sync.RWMutex
 }
 
+// fakeDefaultVaultIcon is the icon set by 1password when creating a vault without icon.
+const fakeDefaultVaultIcon = "vault-door"
+
 func NewRepository(fakeFilePath string) (storage.Repository, error) {
 	// Try loading state from disk.
 	// Ignore if file doesn't exists, it means its new storage.
@@ -48,6 +54,11 @@ func NewRepository(fakeFilePath string) (storage.Repository, error) {
 		vaults = fks.Vaults
 	}
 
+	vaultItems := map[string]int{}
+	if fks != nil && fks.VaultItems != nil {
+		vaultItems = fks.VaultItems
+	}
+
 	vaultGroupAccess := map[string]model.VaultGroupAccess{}
 	if fks != nil && fks.VaultGroupAccess != nil {
 		vaultGroupAccess = fks.VaultGroupAccess
@@ -64,6 +75,7 @@ func NewRepository(fakeFilePath string) (storage.Repository, error) {
 		groupsByID:           groups,
 		membershipByID:       members,
 		vaultsByID:           vaults,
+		vaultItemsByID:       vaultItems,
 		vaultGroupAccessByID: vaultGroupAccess,
 		vaultUserAccessByID:  vaultUserAccess,
 	}, nil
@@ -79,7 +91,11 @@ func (r *repository) CreateUser(ctx context.Context, user model.User) (*model.Us
 		return nil, fmt.Errorf("user already exists")
 	}
 
+	now := time.Now().UTC()
 	user.ID = id
+	user.State = model.UserStateActive
+	user.CreatedAt = now
+	user.UpdatedAt = now
 	r.usersByID[user.ID] = user
 
 	err := r.dumpStorage()
@@ -96,7 +112,7 @@ func (r *repository) GetUserByID(ctx context.Context, id string) (*model.User, e
 
 	user, ok := r.usersByID[id]
 	if !ok {
-		return nil, fmt.Errorf("user does not exists")
+		return nil, fmt.Errorf("user does not exists: %w", storage.ErrNotFound)
 	}
 
 	return &user, nil
@@ -113,19 +129,31 @@ func (r *repository) GetUserByEmail(ctx context.Context, email string) (*model.U
 		}
 	}
 
-	return nil, fmt.Errorf("user does not exists")
+	return nil, fmt.Errorf("user does not exists: %w", storage.ErrNotFound)
 }
 
 func (r *repository) EnsureUser(ctx context.Context, user model.User) (*model.User, error) {
 	r.storageMu.Lock()
 	defer r.storageMu.Unlock()
 
-	_, ok := r.usersByID[user.ID]
+	current, ok := r.usersByID[user.ID]
 	if !ok {
-		return nil, fmt.Errorf("user doesn't exists")
+		return nil, fmt.Errorf("user doesn't exists: %w", storage.ErrNotFound)
 	}
 
-	r.usersByID[user.Email] = user
+	// The email can be changed, but it must be unique.
+	for id, u := range r.usersByID {
+		if id != user.ID && u.Email == user.Email {
+			return nil, fmt.Errorf("user with email %q already exists", user.Email)
+		}
+	}
+
+	// The state is changed with suspend and reactivate.
+	user.State = current.State
+	user.Type = current.Type
+	user.CreatedAt = current.CreatedAt
+	user.UpdatedAt = time.Now().UTC()
+	r.usersByID[user.ID] = user
 
 	err := r.dumpStorage()
 	if err != nil {
@@ -141,7 +169,7 @@ func (r *repository) DeleteUser(ctx context.Context, id string) error {
 
 	_, ok := r.usersByID[id]
 	if !ok {
-		return fmt.Errorf("user doesn't exists")
+		return fmt.Errorf("user doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.usersByID, id)
@@ -154,6 +182,43 @@ func (r *repository) DeleteUser(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *repository) SuspendUser(ctx context.Context, id string) error {
+	return r.setUserState(id, model.UserStateSuspended)
+}
+
+func (r *repository) ReactivateUser(ctx context.Context, id string) error {
+	return r.setUserState(id, model.UserStateActive)
+}
+
+func (r *repository) setUserState(id string, state model.UserState) error {
+	r.storageMu.Lock()
+	defer r.storageMu.Unlock()
+
+	user, ok := r.usersByID[id]
+	if !ok {
+		return fmt.Errorf("user doesn't exists: %w", storage.ErrNotFound)
+	}
+
+	user.State = state
+	user.UpdatedAt = time.Now().UTC()
+	r.usersByID[id] = user
+
+	return r.dumpStorage()
+}
+
+func (r *repository) ListUsers(ctx context.Context) ([]model.User, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	users := make([]model.User, 0, len(r.usersByID))
+	for _, v := range r.usersByID {
+		users = append(users, v)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+
+	return users, nil
+}
+
 func (r *repository) CreateGroup(ctx context.Context, group model.Group) (*model.Group, error) {
 	r.storageMu.Lock()
 	defer r.storageMu.Unlock()
@@ -181,7 +246,7 @@ func (r *repository) GetGroupByID(ctx context.Context, id string) (*model.Group,
 
 	group, ok := r.groupsByID[id]
 	if !ok {
-		return nil, fmt.Errorf("group does not exists")
+		return nil, fmt.Errorf("group does not exists: %w", storage.ErrNotFound)
 	}
 
 	return &group, nil
@@ -198,7 +263,7 @@ func (r *repository) GetGroupByName(ctx context.Context, name string) (*model.Gr
 		}
 	}
 
-	return nil, fmt.Errorf("group does not exists")
+	return nil, fmt.Errorf("group does not exists: %w", storage.ErrNotFound)
 }
 
 func (r *repository) EnsureGroup(ctx context.Context, group model.Group) (*model.Group, error) {
@@ -207,10 +272,17 @@ func (r *repository) EnsureGroup(ctx context.Context, group model.Group) (*model
 
 	_, ok := r.groupsByID[group.ID]
 	if !ok {
-		return nil, fmt.Errorf("group doesn't exists")
+		return nil, fmt.Errorf("group doesn't exists: %w", storage.ErrNotFound)
 	}
 
-	r.groupsByID[group.Name] = group
+	// The name can be changed, but it must be unique.
+	for id, v := range r.groupsByID {
+		if id != group.ID && v.Name == group.Name {
+			return nil, fmt.Errorf("group with name %q already exists", group.Name)
+		}
+	}
+
+	r.groupsByID[group.ID] = group
 
 	err := r.dumpStorage()
 	if err != nil {
@@ -226,7 +298,7 @@ func (r *repository) DeleteGroup(ctx context.Context, id string) error {
 
 	_, ok := r.groupsByID[id]
 	if !ok {
-		return fmt.Errorf("group doesn't exists")
+		return fmt.Errorf("group doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.groupsByID, id)
@@ -239,6 +311,19 @@ func (r *repository) DeleteGroup(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *repository) ListGroups(ctx context.Context) ([]model.Group, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	groups := make([]model.Group, 0, len(r.groupsByID))
+	for _, v := range r.groupsByID {
+		groups = append(groups, v)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups, nil
+}
+
 func (r *repository) getMembershipID(groupID, userID string) string {
 	return groupID + "/" + userID
 }
@@ -247,6 +332,12 @@ func (r *repository) EnsureMembership(ctx context.Context, membership model.Memb
 	r.storageMu.Lock()
 	defer r.storageMu.Unlock()
 
+	// Guests can't be part of groups.
+	user, ok := r.usersByID[membership.UserID]
+	if ok && user.Type == model.UserTypeGuest {
+		return fmt.Errorf("guest users can't be added to groups")
+	}
+
 	id := r.getMembershipID(membership.GroupID, membership.UserID)
 	r.membershipByID[id] = membership
 
@@ -266,7 +357,7 @@ func (r *repository) DeleteMembership(ctx context.Context, membership model.Memb
 
 	_, ok := r.membershipByID[id]
 	if !ok {
-		return fmt.Errorf("membership doesn't exists")
+		return fmt.Errorf("membership doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.membershipByID, id)
@@ -286,23 +377,49 @@ func (r *repository) GetMembershipByID(ctx context.Context, groupID, userID stri
 	id := r.getMembershipID(groupID, userID)
 	m, ok := r.membershipByID[id]
 	if !ok {
-		return nil, fmt.Errorf("membership doesn't exists")
+		return nil, fmt.Errorf("membership doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	return &m, nil
 }
 
+func (r *repository) ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	members := []model.GroupMember{}
+	for _, m := range r.membershipByID {
+		if m.GroupID != groupID {
+			continue
+		}
+
+		// Memberships can be set on users that are not managed by the fake storage.
+		user, ok := r.usersByID[m.UserID]
+		if !ok {
+			user = model.User{ID: m.UserID}
+		}
+
+		members = append(members, model.GroupMember{User: user, Role: m.Role})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].User.ID < members[j].User.ID })
+
+	return members, nil
+}
+
 func (r *repository) CreateVault(ctx context.Context, vault model.Vault) (*model.Vault, error) {
 	r.storageMu.Lock()
 	defer r.storageMu.Unlock()
 
 	id := vault.Name
-	_, ok := r.groupsByID[id]
+	_, ok := r.vaultsByID[id]
 	if ok {
 		return nil, fmt.Errorf("vault already exists")
 	}
 
 	vault.ID = id
+	if vault.Icon == "" {
+		vault.Icon = fakeDefaultVaultIcon
+	}
 	r.vaultsByID[vault.ID] = vault
 
 	err := r.dumpStorage()
@@ -319,7 +436,7 @@ func (r *repository) GetVaultByID(ctx context.Context, id string) (*model.Vault,
 
 	vault, ok := r.vaultsByID[id]
 	if !ok {
-		return nil, fmt.Errorf("vault does not exists")
+		return nil, fmt.Errorf("vault does not exists: %w", storage.ErrNotFound)
 	}
 
 	return &vault, nil
@@ -336,19 +453,34 @@ func (r *repository) GetVaultByName(ctx context.Context, name string) (*model.Va
 		}
 	}
 
-	return nil, fmt.Errorf("vault does not exists")
+	return nil, fmt.Errorf("vault does not exists: %w", storage.ErrNotFound)
 }
 
 func (r *repository) EnsureVault(ctx context.Context, vault model.Vault) (*model.Vault, error) {
 	r.storageMu.Lock()
 	defer r.storageMu.Unlock()
 
-	_, ok := r.vaultsByID[vault.ID]
+	current, ok := r.vaultsByID[vault.ID]
 	if !ok {
-		return nil, fmt.Errorf("vault doesn't exists")
+		return nil, fmt.Errorf("vault doesn't exists: %w", storage.ErrNotFound)
 	}
 
-	r.vaultsByID[vault.Name] = vault
+	// Not setting the icon keeps the current one.
+	if vault.Icon == "" {
+		vault.Icon = current.Icon
+	}
+
+	// Like op, if the admins can manage the vault can only be set on creation.
+	vault.AllowAdminsToManage = current.AllowAdminsToManage
+
+	// The name can be changed, but it must be unique.
+	for id, v := range r.vaultsByID {
+		if id != vault.ID && v.Name == vault.Name {
+			return nil, fmt.Errorf("vault with name %q already exists", vault.Name)
+		}
+	}
+
+	r.vaultsByID[vault.ID] = vault
 
 	err := r.dumpStorage()
 	if err != nil {
@@ -364,10 +496,11 @@ func (r *repository) DeleteVault(ctx context.Context, id string) error {
 
 	_, ok := r.vaultsByID[id]
 	if !ok {
-		return fmt.Errorf("vault doesn't exists")
+		return fmt.Errorf("vault doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.vaultsByID, id)
+	delete(r.vaultItemsByID, id)
 
 	err := r.dumpStorage()
 	if err != nil {
@@ -377,6 +510,47 @@ func (r *repository) DeleteVault(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *repository) CountVaultItems(ctx context.Context, id string) (int, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	_, ok := r.vaultsByID[id]
+	if !ok {
+		return 0, fmt.Errorf("vault doesn't exists: %w", storage.ErrNotFound)
+	}
+
+	return r.vaultItemsByID[id], nil
+}
+
+// SetVaultItems sets the number of items of a vault. Items are not managed by the provider, so this
+// is the way of faking them (e.g: in tests).
+func (r *repository) SetVaultItems(ctx context.Context, id string, items int) error {
+	r.storageMu.Lock()
+	defer r.storageMu.Unlock()
+
+	_, ok := r.vaultsByID[id]
+	if !ok {
+		return fmt.Errorf("vault doesn't exists: %w", storage.ErrNotFound)
+	}
+
+	r.vaultItemsByID[id] = items
+
+	return r.dumpStorage()
+}
+
+func (r *repository) ListVaults(ctx context.Context) ([]model.Vault, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	vaults := make([]model.Vault, 0, len(r.vaultsByID))
+	for _, v := range r.vaultsByID {
+		vaults = append(vaults, v)
+	}
+	sort.Slice(vaults, func(i, j int) bool { return vaults[i].Name < vaults[j].Name })
+
+	return vaults, nil
+}
+
 func (r *repository) getVaultGroupAccessID(vaultID, groupID string) string {
 	return vaultID + "/" + groupID
 }
@@ -404,7 +578,7 @@ func (r *repository) DeleteVaultGroupAccess(ctx context.Context, vaultID string,
 
 	_, ok := r.vaultGroupAccessByID[id]
 	if !ok {
-		return fmt.Errorf("vault access doesn't exists")
+		return fmt.Errorf("vault access doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.vaultGroupAccessByID, id)
@@ -424,12 +598,27 @@ func (r *repository) GetVaultGroupAccessByID(ctx context.Context, vaultID string
 	id := r.getVaultGroupAccessID(vaultID, groupID)
 	v, ok := r.vaultGroupAccessByID[id]
 	if !ok {
-		return nil, fmt.Errorf("vault access doesn't exists")
+		return nil, fmt.Errorf("vault access doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	return &v, nil
 }
 
+func (r *repository) ListVaultGroupAccesses(ctx context.Context, vaultID string) ([]model.VaultGroupAccess, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	accesses := []model.VaultGroupAccess{}
+	for _, a := range r.vaultGroupAccessByID {
+		if a.VaultID == vaultID {
+			accesses = append(accesses, a)
+		}
+	}
+	sort.Slice(accesses, func(i, j int) bool { return accesses[i].GroupID < accesses[j].GroupID })
+
+	return accesses, nil
+}
+
 func (r *repository) getVaultUserAccessID(vaultID, userID string) string {
 	return vaultID + "/" + userID
 }
@@ -457,7 +646,7 @@ func (r *repository) DeleteVaultUserAccess(ctx context.Context, vaultID string,
 
 	_, ok := r.vaultUserAccessByID[id]
 	if !ok {
-		return fmt.Errorf("vault access doesn't exists")
+		return fmt.Errorf("vault access doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	delete(r.vaultUserAccessByID, id)
@@ -477,17 +666,33 @@ func (r *repository) GetVaultUserAccessByID(ctx context.Context, vaultID string,
 	id := r.getVaultUserAccessID(vaultID, userID)
 	v, ok := r.vaultUserAccessByID[id]
 	if !ok {
-		return nil, fmt.Errorf("vault access doesn't exists")
+		return nil, fmt.Errorf("vault access doesn't exists: %w", storage.ErrNotFound)
 	}
 
 	return &v, nil
 }
 
+func (r *repository) ListVaultUserAccesses(ctx context.Context, vaultID string) ([]model.VaultUserAccess, error) {
+	r.storageMu.RLock()
+	defer r.storageMu.RUnlock()
+
+	accesses := []model.VaultUserAccess{}
+	for _, a := range r.vaultUserAccessByID {
+		if a.VaultID == vaultID {
+			accesses = append(accesses, a)
+		}
+	}
+	sort.Slice(accesses, func(i, j int) bool { return accesses[i].UserID < accesses[j].UserID })
+
+	return accesses, nil
+}
+
 type fakeStorage struct {
 	Users            map[string]model.User
 	Groups           map[string]model.Group
 	Members          map[string]model.Membership
 	Vaults           map[string]model.Vault
+	VaultItems       map[string]int
 	VaultGroupAccess map[string]model.VaultGroupAccess
 	VaultUserAccess  map[string]model.VaultUserAccess
 }
@@ -498,6 +703,7 @@ func (r *repository) dumpStorage() error {
 		Groups:           r.groupsByID,
 		Members:          r.membershipByID,
 		Vaults:           r.vaultsByID,
+		VaultItems:       r.vaultItemsByID,
 		VaultGroupAccess: r.vaultGroupAccessByID,
 		VaultUserAccess:  r.vaultUserAccessByID,
 	}
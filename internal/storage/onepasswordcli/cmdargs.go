@@ -1,6 +1,9 @@
 package onepasswordcli
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 type onePasswordCliCmd struct {
 	args []string
@@ -40,6 +43,16 @@ func (o *onePasswordCliCmd) DeleteArg() *onePasswordCliCmd {
 	return o
 }
 
+func (o *onePasswordCliCmd) SuspendArg() *onePasswordCliCmd {
+	o.args = append(o.args, "suspend")
+	return o
+}
+
+func (o *onePasswordCliCmd) ReactivateArg() *onePasswordCliCmd {
+	o.args = append(o.args, "reactivate")
+	return o
+}
+
 func (o *onePasswordCliCmd) GrantArg() *onePasswordCliCmd {
 	o.args = append(o.args, "grant")
 	return o
@@ -112,11 +125,34 @@ func (o *onePasswordCliCmd) EmailFlag(email string) *onePasswordCliCmd {
 	return o
 }
 
+func (o *onePasswordCliCmd) GuestFlag(guest bool) *onePasswordCliCmd {
+	if !guest {
+		return o
+	}
+
+	o.args = append(o.args, "--guest")
+	return o
+}
+
 func (o *onePasswordCliCmd) VaultFlag(id string) *onePasswordCliCmd {
 	o.args = append(o.args, "--vault", id)
 	return o
 }
 
+func (o *onePasswordCliCmd) IconFlag(icon string) *onePasswordCliCmd {
+	if icon == "" {
+		return o
+	}
+
+	o.args = append(o.args, "--icon", icon)
+	return o
+}
+
+func (o *onePasswordCliCmd) AllowAdminsToManageFlag(allow bool) *onePasswordCliCmd {
+	o.args = append(o.args, "--allow-admins-to-manage", strconv.FormatBool(allow))
+	return o
+}
+
 func (o *onePasswordCliCmd) NoInputFlag() *onePasswordCliCmd {
 	o.args = append(o.args, "--no-input")
 	return o
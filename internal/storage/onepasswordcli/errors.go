@@ -0,0 +1,38 @@
+package onepasswordcli
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+// opNotFoundRegexp matches the op CLI errors when a resource is missing, e.g:
+//
+//	[ERROR] 2022/03/14 17:13:39 "test@slok.dev" isn't a user in this account. Specify the user with their UUID, email address, or name.
+var opNotFoundRegexp = regexp.MustCompile(`isn't an? (user|vault|group)\b`)
+
+// newOpCmdError returns the error of a failed op CLI command, known op errors
+// will be mapped to storage errors so they can be checked by the callers.
+func newOpCmdError(err error, stderr string) error {
+	if opNotFoundRegexp.MatchString(stderr) {
+		return fmt.Errorf("op cli command failed: %w: %w: %s", storage.ErrNotFound, err, stderr)
+	}
+
+	return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+}
+
+// opSessionExpiredRegexp matches the op CLI errors when the session is not valid anymore, e.g:
+//
+//	[ERROR] 2022/03/14 17:13:39 You are not currently signed in. Please run `op signin --help` for instructions
+//	[ERROR] 2022/03/14 17:13:39 session expired, sign in to create a new session
+var opSessionExpiredRegexp = regexp.MustCompile(`(?i)(session expired|not currently signed in|authentication required)`)
+
+// opRetryableRegexp matches the op CLI errors that are safe to retry, e.g:
+//
+//	[ERROR] 2022/03/14 17:13:39 Too many requests. Please wait a while before trying again.
+//
+// Only throttling errors are retried, the API rejected these before doing anything. Other
+// temporary errors (timeouts, 50x, connection resets...) could happen after the change has
+// been applied, and retrying non idempotent commands (e.g: create, grant) is not safe.
+var opRetryableRegexp = regexp.MustCompile(`(?i)(too many requests|rate limit|\b429\b)`)
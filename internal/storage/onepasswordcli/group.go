@@ -21,7 +21,7 @@ func (r Repository) CreateGroup(ctx context.Context, group model.Group) (*model.
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	og := opGroup{}
@@ -40,7 +40,7 @@ func (r Repository) GetGroupByID(ctx context.Context, id string) (*model.Group,
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	og := opGroup{}
@@ -60,7 +60,7 @@ func (r Repository) GetGroupByName(ctx context.Context, name string) (*model.Gro
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	og := opGroup{}
@@ -75,12 +75,18 @@ func (r Repository) GetGroupByName(ctx context.Context, name string) (*model.Gro
 }
 
 func (r Repository) EnsureGroup(ctx context.Context, group model.Group) (*model.Group, error) {
+	// Same as on creation, make sure the name (in case it has been renamed) is not used by another group.
+	current, err := r.GetGroupByName(ctx, group.Name)
+	if err == nil && current.ID != group.ID {
+		return nil, fmt.Errorf("group with name %q already exists", group.Name)
+	}
+
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.GroupArg().EditArg().RawStrArg(group.ID).DescriptionFlag(group.Description)
+	cmdArgs.GroupArg().EditArg().RawStrArg(group.ID).NameFlag(group.Name).DescriptionFlag(group.Description)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	return &group, nil
@@ -91,12 +97,35 @@ func (r Repository) DeleteGroup(ctx context.Context, id string) error {
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
+func (r Repository) ListGroups(ctx context.Context) ([]model.Group, error) {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.GroupArg().ListArg().FormatJSONFlag()
+
+	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return nil, newOpCmdError(err, stderr)
+	}
+
+	ogs := []opGroup{}
+	err = json.Unmarshal([]byte(stdout), &ogs)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	groups := make([]model.Group, 0, len(ogs))
+	for _, og := range ogs {
+		groups = append(groups, mapOpToModelGroup(og))
+	}
+
+	return groups, nil
+}
+
 type opGroup struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -88,10 +89,11 @@ func TestRepositoryCreateGroup(t *testing.T) {
 
 func TestRepositoryGetGroupByID(t *testing.T) {
 	tests := map[string]struct {
-		id       string
-		mock     func(m *onepasswordclimock.OpCli)
-		expGroup *model.Group
-		expErr   bool
+		id          string
+		mock        func(m *onepasswordclimock.OpCli)
+		expGroup    *model.Group
+		expErr      bool
+		expNotFound bool
 	}{
 		"Getting a group correctly, should return the group data.": {
 			id: "test-id",
@@ -107,6 +109,17 @@ func TestRepositoryGetGroupByID(t *testing.T) {
 			},
 		},
 
+		"Getting a missing group, should fail with a not found error.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group get test-id --format json`
+				stderr := `[ERROR] 2022/03/14 17:13:39 "test-id" isn't a group in this account.`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr:      true,
+			expNotFound: true,
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			id: "test-id",
 			mock: func(m *onepasswordclimock.OpCli) {
@@ -132,6 +145,9 @@ func TestRepositoryGetGroupByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expGroup, gotGroup)
 			}
@@ -151,16 +167,46 @@ func TestRepositoryEnsureGroup(t *testing.T) {
 		"Updating a group correctly, should update the group data.": {
 			group: model.Group{ID: "test-id", Name: "test-00", Description: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `group edit test-id --description Test00`
+				expCmd := `group get test-00 --format json`
+				stdout := `{"id":"test-id","name":"test-00","description":"Test"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `group edit test-id --name test-00 --description Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
 			},
 			expGroup: &model.Group{ID: "test-id", Name: "test-00", Description: "Test00"},
 		},
 
+		"Renaming a group, should update the group name.": {
+			group: model.Group{ID: "test-id", Name: "test-01", Description: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group get test-01 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("group doesn't exist"))
+
+				expCmd = `group edit test-id --name test-01 --description Test00`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+			expGroup: &model.Group{ID: "test-id", Name: "test-01", Description: "Test00"},
+		},
+
+		"Renaming a group with the name of another group, should fail.": {
+			group: model.Group{ID: "test-id", Name: "test-01", Description: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group get test-01 --format json`
+				stdout := `{"id":"other-id","name":"test-01","description":"Test01"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expErr: true,
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			group: model.Group{ID: "test-id", Name: "test-00", Description: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `group edit test-id --description Test00`
+				expCmd := `group get test-00 --format json`
+				stdout := `{"id":"test-id","name":"test-00","description":"Test"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `group edit test-id --name test-00 --description Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
@@ -238,3 +284,62 @@ func TestRepositoryDeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryListGroups(t *testing.T) {
+	tests := map[string]struct {
+		mock      func(m *onepasswordclimock.OpCli)
+		expGroups []model.Group
+		expErr    bool
+	}{
+		"Listing the groups correctly, should return the groups data.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group list --format json`
+				stdout := `[{"id":"test-group-00","name":"group-00","description":"Group 00"},{"id":"test-group-01","name":"group-01","description":"Group 01"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expGroups: []model.Group{
+				{ID: "test-group-00", Name: "group-00", Description: "Group 00"},
+				{ID: "test-group-01", Name: "group-01", Description: "Group 01"},
+			},
+		},
+
+		"Listing without groups, should return an empty list.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+			},
+			expGroups: []model.Group{},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `group list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotGroups, err := repo.ListGroups(context.TODO())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expGroups, gotGroups)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package onepasswordcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// minOpCliVersion is the minimum op version supported, the provider uses op v2 commands and JSON formats.
+	minOpCliVersion = "2.0.0"
+	// minOpCliServiceAccountVersion is the minimum op version that supports service accounts.
+	minOpCliServiceAccountVersion = "2.18.0"
+)
+
+// OpInfo is the information of the op CLI and the signed in account.
+type OpInfo struct {
+	// Version is the op CLI version.
+	Version string
+	// AccountURL is the signed in account URL (e.g: https://my.1password.com).
+	AccountURL  string
+	AccountUUID string
+	UserUUID    string
+	// UserEmail is the signed in user email, empty for service accounts.
+	UserEmail string
+	// UserType is the signed in user type (e.g: `HUMAN`, `SERVICE_ACCOUNT`).
+	UserType string
+}
+
+// checkOpCliVersion checks the op CLI version is supported and returns it.
+func checkOpCliVersion(ctx context.Context, cli OpCli, minVersion string) (string, error) {
+	stdout, stderr, err := cli.RunOpCmd(ctx, []string{"--version"})
+	if err != nil {
+		return "", fmt.Errorf("could not get op cli version, check op is installed: %w: %s", err, stderr)
+	}
+
+	rawVersion := strings.TrimSpace(stdout)
+	v, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return "", fmt.Errorf("could not parse op cli version %q: %w", rawVersion, err)
+	}
+
+	if v.LessThan(version.Must(version.NewVersion(minVersion))) {
+		return "", fmt.Errorf("op cli version %s is not supported, the minimum version is %s, upgrade op (https://developer.1password.com/docs/cli/get-started/)", v, minVersion)
+	}
+
+	return v.String(), nil
+}
+
+type opWhoami struct {
+	URL         string `json:"url"`
+	Email       string `json:"email"`
+	UserUUID    string `json:"user_uuid"`
+	AccountUUID string `json:"account_uuid"`
+	UserType    string `json:"user_type"`
+}
+
+// getOpInfo checks the op CLI can execute commands on the account and returns the op information.
+func getOpInfo(ctx context.Context, cli OpCli, opVersion string) (*OpInfo, error) {
+	stdout, stderr, err := cli.RunOpCmd(ctx, []string{"whoami", "--format", "json"})
+	if err != nil {
+		return nil, fmt.Errorf("could not get op cli signed in account: %w", newOpCmdError(err, stderr))
+	}
+
+	ow := opWhoami{}
+	err = json.Unmarshal([]byte(stdout), &ow)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	return &OpInfo{
+		Version:     opVersion,
+		AccountURL:  ow.URL,
+		AccountUUID: ow.AccountUUID,
+		UserUUID:    ow.UserUUID,
+		UserEmail:   ow.Email,
+		UserType:    ow.UserType,
+	}, nil
+}
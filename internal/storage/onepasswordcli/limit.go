@@ -0,0 +1,43 @@
+package onepasswordcli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NewConcurrencyLimitOpCli returns an OpCli that limits the number of op commands executing at the
+// same time, the rest of the commands will wait until they can be executed.
+func NewConcurrencyLimitOpCli(cli OpCli, maxConcurrent int) (OpCli, error) {
+	if maxConcurrent < 1 {
+		return nil, fmt.Errorf("max concurrent commands must be at least 1")
+	}
+
+	return concurrencyLimitOpCli{
+		cli: cli,
+		sem: make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+type concurrencyLimitOpCli struct {
+	cli OpCli
+	sem chan struct{}
+}
+
+func (c concurrencyLimitOpCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
+	start := time.Now()
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return "", "", fmt.Errorf("op command cancelled while waiting to be executed: %w", ctx.Err())
+	}
+	defer func() { <-c.sem }()
+
+	tflog.Debug(ctx, "op command waited in concurrency limit queue", map[string]interface{}{
+		"wait": time.Since(start).String(),
+	})
+
+	return c.cli.RunOpCmd(ctx, args)
+}
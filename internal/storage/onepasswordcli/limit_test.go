@@ -0,0 +1,112 @@
+package onepasswordcli_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
+)
+
+func TestConcurrencyLimitOpCliRunOpCmd(t *testing.T) {
+	tests := map[string]struct {
+		maxConcurrent int
+		commands      int
+		expMaxRunning int32
+	}{
+		"Executing less commands than the limit, should execute all at the same time.": {
+			maxConcurrent: 5,
+			commands:      3,
+			expMaxRunning: 3,
+		},
+
+		"Executing more commands than the limit, should not execute more than the limit at the same time.": {
+			maxConcurrent: 2,
+			commands:      10,
+			expMaxRunning: 2,
+		},
+
+		"Executing commands with a limit of one, should execute them sequentially.": {
+			maxConcurrent: 1,
+			commands:      5,
+			expMaxRunning: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			// Track the commands executing at the same time.
+			var running, maxRunning int32
+			mc := &onepasswordclimock.OpCli{}
+			mc.On("RunOpCmd", mock.Anything, strings.Fields("user list")).Times(test.commands).Return("", "", nil).Run(func(mock.Arguments) {
+				r := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			})
+
+			cli, err := onepasswordcli.NewConcurrencyLimitOpCli(mc, test.maxConcurrent)
+			require.NoError(err)
+
+			var wg sync.WaitGroup
+			for i := 0; i < test.commands; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _, err := cli.RunOpCmd(context.TODO(), strings.Fields("user list"))
+					assert.NoError(err)
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(test.expMaxRunning, maxRunning)
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConcurrencyLimitOpCliRunOpCmdCancelledWhileWaiting(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// Block the only execution slot.
+	release := make(chan struct{})
+	mc := &onepasswordclimock.OpCli{}
+	mc.On("RunOpCmd", mock.Anything, strings.Fields("user list")).Once().Return("", "", nil).Run(func(mock.Arguments) { <-release })
+
+	cli, err := onepasswordcli.NewConcurrencyLimitOpCli(mc, 1)
+	require.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _, _ = cli.RunOpCmd(context.TODO(), strings.Fields("user list"))
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// The waiting command should be cancelled without being executed.
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, _, err = cli.RunOpCmd(ctx, strings.Fields("user list"))
+	assert.ErrorIs(err, context.Canceled)
+
+	close(release)
+	<-done
+	mc.AssertExpectations(t)
+}
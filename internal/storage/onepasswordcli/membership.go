@@ -3,10 +3,12 @@ package onepasswordcli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
 func (r Repository) EnsureMembership(ctx context.Context, membership model.Membership) error {
@@ -15,67 +17,124 @@ func (r Repository) EnsureMembership(ctx context.Context, membership model.Membe
 		return fmt.Errorf("could not map role: %w", err)
 	}
 
+	// Check the current membership to know if we need to add the member, change its role or nothing.
+	current, err := r.GetMembershipByID(ctx, membership.GroupID, membership.UserID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get current membership: %w", err)
+	}
+
+	switch {
+	case current == nil:
+		return r.addMembership(ctx, membership, role)
+	case current.Role == membership.Role:
+		return nil
+	default:
+		return r.changeMembershipRole(ctx, membership, role)
+	}
+}
+
+func (r Repository) grantMembership(ctx context.Context, groupID, userID, role string) error {
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.GroupArg().UserArg().GrantArg().UserFlag(membership.UserID).GroupFlag(membership.GroupID).RoleFlag(role)
+	cmdArgs.GroupArg().UserArg().GrantArg().UserFlag(userID).GroupFlag(groupID).RoleFlag(role)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
+	}
+
+	return nil
+}
+
+// addMembership adds a new member to the group.
+//
+// 1password doesn't know to add a member to a group with a specific role, so we would need to:
+// - Add user to group.
+// - Change role.
+// By default 1passwords add users as members, so in case the role is other than that make a second
+// call always. We assume this tradeoff of extra call in these cases to avoid the need to apply
+// tf twice.
+func (r Repository) addMembership(ctx context.Context, membership model.Membership, role string) error {
+	err := r.grantMembership(ctx, membership.GroupID, membership.UserID, role)
+	if err != nil {
+		return err
 	}
 
-	// 1password doesn't know to add a member to a group with a specific role, so we would need to:
-	// - Add user to group.
-	// - Change role.
-	// By default 1passwords add users as members, so in case the role is other than that make a second
-	// call always. We assume this tradeoff of extra call in these cases to avoid the need to apply
-	// tf twice.
 	if membership.Role != model.MembershipRoleMember {
-		_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
-		if err != nil {
-			return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
-		}
+		return r.grantMembership(ctx, membership.GroupID, membership.UserID, role)
 	}
 
 	return nil
 }
 
-func (r Repository) GetMembershipByID(ctx context.Context, groupID, userID string) (*model.Membership, error) {
-	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.UserArg().ListArg().GroupFlag(groupID).FormatJSONFlag()
+// changeMembershipRole changes the role of an existing group member.
+//
+// op doesn't have a specific command to change the role of a member, the way of changing it is granting
+// the user again with the new role. However, some accounts ignore the role when the user is already a member,
+// so we check the role has been changed. We don't fallback to revoking and granting the user again, if the
+// grant failed after the revoke, the user would end without membership, so we fail instead and let the
+// user decide (e.g: recreating the `onepasswordorg_group_member` resource).
+func (r Repository) changeMembershipRole(ctx context.Context, membership model.Membership, role string) error {
+	err := r.grantMembership(ctx, membership.GroupID, membership.UserID, role)
+	if err != nil {
+		return err
+	}
 
-	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	current, err := r.GetMembershipByID(ctx, membership.GroupID, membership.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return fmt.Errorf("could not get current membership: %w", err)
+	}
+
+	if current.Role != membership.Role {
+		return fmt.Errorf("op ignored the role change of user %q in group %q from %q to %q, the membership needs to be revoked and granted again with the new role", membership.UserID, membership.GroupID, current.Role, membership.Role)
 	}
 
+	return nil
+}
+
+func (r Repository) GetMembershipByID(ctx context.Context, groupID, userID string) (*model.Membership, error) {
 	// List group members and get the user.
-	members := []opGroupMember{}
-	err = json.Unmarshal([]byte(stdout), &members)
+	members, err := r.ListGroupMembers(ctx, groupID)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+		return nil, err
 	}
-	var member *opGroupMember
+
 	for _, m := range members {
-		if m.ID == userID {
-			member = &m
-			break
+		if m.User.ID == userID {
+			return &model.Membership{
+				UserID:  userID,
+				GroupID: groupID,
+				Role:    m.Role,
+			}, nil
 		}
 	}
 
-	if member == nil {
-		return nil, fmt.Errorf("member %q in group %q not found", userID, groupID)
+	return nil, fmt.Errorf("member %q in group %q: %w", userID, groupID, storage.ErrNotFound)
+}
+
+func (r Repository) ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error) {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.UserArg().ListArg().GroupFlag(groupID).FormatJSONFlag()
+
+	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return nil, newOpCmdError(err, stderr)
 	}
 
-	role, err := mapOpToModelRole(member.Role)
+	members := []opGroupMember{}
+	err = json.Unmarshal([]byte(stdout), &members)
 	if err != nil {
-		return nil, fmt.Errorf("invalid role: %w", err)
+		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	gotMembers := make([]model.GroupMember, 0, len(members))
+	for _, m := range members {
+		gotMembers = append(gotMembers, model.GroupMember{
+			User: mapOpToModelUser(m.opUser),
+			Role: mapOpToModelRole(m.Role),
+		})
 	}
 
-	return &model.Membership{
-		UserID:  userID,
-		GroupID: groupID,
-		Role:    role,
-	}, nil
+	return gotMembers, nil
 }
 
 func (r Repository) DeleteMembership(ctx context.Context, membership model.Membership) error {
@@ -84,14 +143,14 @@ func (r Repository) DeleteMembership(ctx context.Context, membership model.Membe
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
 type opGroupMember struct {
-	ID   string `json:"id"`
+	opUser
 	Role string `json:"role"`
 }
 
@@ -106,13 +165,13 @@ func mapModelToOpRole(m model.MembershipRole) (string, error) {
 	return "", fmt.Errorf("invalid role")
 }
 
-func mapOpToModelRole(role string) (model.MembershipRole, error) {
+// mapOpToModelRole maps the op group role, roles unknown by the provider (e.g: new ones added by 1password)
+// are mapped to member, so a single unknown role doesn't break reading the whole group.
+func mapOpToModelRole(role string) model.MembershipRole {
 	switch strings.ToLower(role) {
-	case "member":
-		return model.MembershipRoleMember, nil
 	case "manager":
-		return model.MembershipRoleManager, nil
+		return model.MembershipRoleManager
 	default:
-		return model.MembershipRoleMember, fmt.Errorf("invalid role")
+		return model.MembershipRoleMember
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -21,26 +22,111 @@ func TestRepositoryEnsureMembership(t *testing.T) {
 		mock       func(m *onepasswordclimock.OpCli)
 		expErr     bool
 	}{
-		"Creating a membership correctly, should return the data with the ID.": {
+		"Creating a membership correctly, should grant the user in the group.": {
 			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleMember},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `group user grant --user test-00 --group group-00 --role member`
+				expCmd := `user list --group group-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role member`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
 			},
 		},
 
-		"If the user has wants a role other than member it shoul be called twice.": {
+		"Creating a membership with a role other than member, should grant the user twice to set the role.": {
 			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleManager},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `group user grant --user test-00 --group group-00 --role manager`
+				expCmd := `user list --group group-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role manager`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Twice().Return("", "", nil)
 			},
 		},
 
+		"Having an error while setting the role of a new membership, should fail.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleManager},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role manager`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+
+		"Ensuring a membership that already has the same role, should not change anything.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleManager},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				stdout := `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MANAGER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+		},
+
+		"Changing a membership from member to manager, should change the role of the user.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleManager},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				stdout := `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MEMBER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role manager`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+
+				expCmd = `user list --group group-00 --format json`
+				stdout = `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MANAGER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+		},
+
+		"Changing a membership from manager to member, should change the role of the user.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleMember},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				stdout := `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MANAGER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role member`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+
+				expCmd = `user list --group group-00 --format json`
+				stdout = `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MEMBER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+		},
+
+		"Changing a membership role when granting ignores the role, should fail without revoking the user.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleManager},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				stdout := `[{"id":"test-00","name":"Test00","email":"test0@slok.dev","role":"MEMBER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Twice().Return(stdout, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role manager`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+			expErr: true,
+		},
+
+		"Having an error while getting the current membership, should fail.": {
+			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleMember},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+
 		"Having an error while calling the create op CLI action, should fail.": {
 			membership: model.Membership{UserID: "test-00", GroupID: "group-00", Role: model.MembershipRoleMember},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `group user grant --user test-00 --group group-00 --role member`
+				expCmd := `user list --group group-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+
+				expCmd = `group user grant --user test-00 --group group-00 --role member`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
@@ -78,6 +164,7 @@ func TestRepositoryGetMembershipByID(t *testing.T) {
 		mock          func(m *onepasswordclimock.OpCli)
 		expMembership *model.Membership
 		expErr        bool
+		expNotFound   bool
 	}{
 		"Getting a member correctly, should return the group data.": {
 			userID:  "test-user-00",
@@ -102,7 +189,8 @@ func TestRepositoryGetMembershipByID(t *testing.T) {
 				stdout := `[{"id":"test-user-00","name":"Test00","email":"test0@slok.dev","role":"MANAGER"},{"id":"test-user-01","name":"Tst01","email":"test01@slok.dev","role":"MEMBER"}]`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
-			expErr: true,
+			expErr:      true,
+			expNotFound: true,
 		},
 
 		"Having an error while calling the op CLI, should fail.": {
@@ -131,6 +219,9 @@ func TestRepositoryGetMembershipByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expMembership, gotMembership)
 			}
@@ -140,6 +231,90 @@ func TestRepositoryGetMembershipByID(t *testing.T) {
 	}
 }
 
+func TestRepositoryListGroupMembers(t *testing.T) {
+	tests := map[string]struct {
+		groupID    string
+		mock       func(m *onepasswordclimock.OpCli)
+		expMembers []model.GroupMember
+		expErr     bool
+	}{
+		"Listing the members correctly, should return the members data.": {
+			groupID: "group-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-id --format json`
+				stdout := `[{"id":"test-user-00","name":"Test00","email":"test0@slok.dev","type":"MEMBER","state":"ACTIVE","role":"MANAGER"},{"id":"test-user-01","name":"Tst01","email":"test01@slok.dev","type":"MEMBER","state":"SUSPENDED","role":"MEMBER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expMembers: []model.GroupMember{
+				{
+					User: model.User{ID: "test-user-00", Name: "Test00", Email: "test0@slok.dev"},
+					Role: model.MembershipRoleManager,
+				},
+				{
+					User: model.User{ID: "test-user-01", Name: "Tst01", Email: "test01@slok.dev", State: model.UserStateSuspended},
+					Role: model.MembershipRoleMember,
+				},
+			},
+		},
+
+		"Listing a group without members, should return an empty list.": {
+			groupID: "group-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+			},
+			expMembers: []model.GroupMember{},
+		},
+
+		"Having an unknown role, should map it to member.": {
+			groupID: "group-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-id --format json`
+				stdout := `[{"id":"test-user-00","name":"Test00","email":"test0@slok.dev","type":"MEMBER","state":"ACTIVE","role":"OWNER"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expMembers: []model.GroupMember{
+				{
+					User: model.User{ID: "test-user-00", Name: "Test00", Email: "test0@slok.dev"},
+					Role: model.MembershipRoleMember,
+				},
+			},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			groupID: "group-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --group group-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotMembers, err := repo.ListGroupMembers(context.TODO(), test.groupID)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expMembers, gotMembers)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRepositoryDeleteMembership(t *testing.T) {
 	tests := map[string]struct {
 		membership model.Membership
@@ -3,11 +3,14 @@ package onepasswordcli
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 )
 
 // OpCli knows how to execute Op CLI commands.
@@ -18,61 +21,261 @@ type OpCli interface {
 
 //go:generate mockery --case underscore --output onepasswordclimock --outpkg onepasswordclimock --name OpCli
 
-type opCli struct {
-	binPath      string
-	sessionToken string
-}
+const (
+	opServiceAccountTokenEnvVar = "OP_SERVICE_ACCOUNT_TOKEN"
+	opConfigDirEnvVar           = "OP_CONFIG_DIR"
+	opAccountShorthandPrefix    = "terraform"
+)
 
 // NewOpCLI creates a new signed OpCLI command executor.
-func NewOpCli(customCliPath, address, email, secretKey, password string) (OpCli, error) {
-	binPath, err := prepareOpCliBinary(customCliPath)
+//
+// The executor will signin again if the op session expires.
+//
+// Before returning, it will check the op version is supported and the account can be used,
+// returning the op information.
+//
+// The account is registered with a unique shorthand on a private op config directory, so
+// multiple executors (e.g: provider aliases) and the user op configuration don't clobber
+// each other. Use Cleanup to signout and remove the config directory.
+func NewOpCli(customCliPath string, useEmbeddedCli bool, address, email, secretKey, password string) (OpCli, *OpInfo, error) {
+	binPath, err := prepareOpCliBinary(customCliPath, useEmbeddedCli)
 	if err != nil {
-		return nil, fmt.Errorf("could not prepare op cli: %w", err)
+		return nil, nil, fmt.Errorf("could not prepare op cli: %w", err)
 	}
 
-	// Login.
-	cmd := exec.Command(binPath, "account", "add", "--address", address, "--email", email, "--secret-key", secretKey, "--shorthand", "terraform", "--signin", "--raw")
-	stdin, err := cmd.StdinPipe()
+	opVersion, err := checkOpCliVersion(context.Background(), binOpCli{binPath: binPath}, minOpCliVersion)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	configDir, err := newOpConfigDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	account, err := newOpAccountShorthand()
+	if err != nil {
+		_ = os.RemoveAll(configDir)
+		return nil, nil, err
+	}
+
+	cli := binOpCli{
+		binPath: binPath,
+		env:     append(os.Environ(), opConfigDirEnvVar+"="+configDir),
 	}
-	go func() {
-		defer stdin.Close()
-		_, err := io.WriteString(stdin, fmt.Sprintf("%s\n", password))
+	signin := newCredentialsSigninFunc(cli, account, address, email, secretKey, password)
+
+	session, err := newSessionOpCli(cli, account, signin)
+	if err != nil {
+		_ = os.RemoveAll(configDir)
+		return nil, nil, err
+	}
+
+	registerCleanup(func(ctx context.Context) error {
+		signoutErr := session.signout(ctx)
+		if err := os.RemoveAll(configDir); err != nil {
+			return errors.Join(signoutErr, fmt.Errorf("could not remove op config dir: %w", err))
+		}
+		return signoutErr
+	})
+
+	info, err := getOpInfo(context.Background(), session, opVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, info, nil
+}
+
+// newCredentialsSigninFunc returns a signin func that uses the account credentials to get an
+// op session.
+//
+// The first signin will register the account on op, the next ones will only signin on the
+// already registered account.
+func newCredentialsSigninFunc(cli binOpCli, account, address, email, secretKey, password string) SigninFunc {
+	accountAdded := false
+	return func(ctx context.Context) (string, error) {
+		args := []string{"signin", "--account", account, "--raw"}
+		if !accountAdded {
+			args = []string{"account", "add", "--address", address, "--email", email, "--secret-key", secretKey, "--shorthand", account, "--signin", "--raw"}
+		}
+
+		// The password is read from stdin, exec writes it and returns the write errors from the
+		// command execution (we can't print them, stdout is used by the Terraform plugin protocol).
+		cmd := exec.CommandContext(ctx, cli.binPath, args...)
+		cmd.Env = cli.env
+		cmd.Stdin = strings.NewReader(password + "\n")
+
+		result, err := cmd.CombinedOutput()
 		if err != nil {
-			fmt.Printf("ERROR: %s\n", err)
+			return "", fmt.Errorf("%w: %s", err, string(result))
 		}
-	}()
+		accountAdded = true
+
+		return strings.TrimSpace(string(result)), nil
+	}
+}
 
-	result, err := cmd.CombinedOutput()
+// NewServiceAccountOpCli creates a new OpCLI command executor authenticated with a 1password
+// service account token.
+//
+// Service accounts don't need to signin, the token is passed to every op command execution.
+// The commands use a private op config directory, use Cleanup to remove it.
+//
+// Before returning, it will check the op version is supported and the token can be used,
+// returning the op information.
+func NewServiceAccountOpCli(customCliPath string, useEmbeddedCli bool, token string) (OpCli, *OpInfo, error) {
+	if token == "" {
+		return nil, nil, fmt.Errorf("service account token is required")
+	}
+
+	binPath, err := prepareOpCliBinary(customCliPath, useEmbeddedCli)
 	if err != nil {
-		return nil, fmt.Errorf("cannot signin: %w: %s", err, string(result))
+		return nil, nil, fmt.Errorf("could not prepare op cli: %w", err)
 	}
 
-	return opCli{
-		binPath:      binPath,
-		sessionToken: strings.TrimSpace(string(result)),
-	}, nil
+	opVersion, err := checkOpCliVersion(context.Background(), binOpCli{binPath: binPath}, minOpCliServiceAccountVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configDir, err := newOpConfigDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registerCleanup(func(ctx context.Context) error {
+		if err := os.RemoveAll(configDir); err != nil {
+			return fmt.Errorf("could not remove op config dir: %w", err)
+		}
+		return nil
+	})
+
+	cli := binOpCli{
+		binPath: binPath,
+		env:     append(os.Environ(), opServiceAccountTokenEnvVar+"="+token, opConfigDirEnvVar+"="+configDir),
+	}
+
+	info, err := getOpInfo(context.Background(), cli, opVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cli, info, nil
+}
+
+// NewExistingSessionOpCli creates a new OpCLI command executor that reuses an already signed in
+// op account instead of signing in (e.g: op desktop app integration, `OP_SESSION_*` env vars).
+//
+// If the session token is set it will be used with the account, otherwise op will use its own
+// session of the account (or the default one if the account is not set). As the session is not
+// ours, it uses the user op configuration and the session is not signed out on Cleanup.
+//
+// Before returning, it will check the op version is supported and the account can be used,
+// returning the op information.
+func NewExistingSessionOpCli(customCliPath string, useEmbeddedCli bool, account, sessionToken string) (OpCli, *OpInfo, error) {
+	if sessionToken != "" && account == "" {
+		return nil, nil, fmt.Errorf("account is required to use a session token")
+	}
+
+	binPath, err := prepareOpCliBinary(customCliPath, useEmbeddedCli)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not prepare op cli: %w", err)
+	}
+
+	cli := binOpCli{binPath: binPath}
+	opVersion, err := checkOpCliVersion(context.Background(), cli, minOpCliVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var opCli OpCli = cli
+	switch {
+	case sessionToken != "":
+		opCli, err = NewSessionOpCli(cli, account, newExistingSessionSigninFunc(sessionToken))
+		if err != nil {
+			return nil, nil, err
+		}
+	case account != "":
+		opCli = accountOpCli{cli: cli, account: account}
+	}
+
+	info, err := getOpInfo(context.Background(), opCli, opVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return opCli, info, nil
+}
+
+// newOpConfigDir creates a private op config directory, op requires the directory
+// to be only accessible by the user.
+func newOpConfigDir() (string, error) {
+	dir, err := os.MkdirTemp("", "terraform-provider-onepasswordorg-op-")
+	if err != nil {
+		return "", fmt.Errorf("could not create op config dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+// newOpAccountShorthand returns a unique account shorthand.
+func newOpAccountShorthand() (string, error) {
+	b := make([]byte, 4)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("could not generate op account shorthand: %w", err)
+	}
+
+	return opAccountShorthandPrefix + "-" + hex.EncodeToString(b), nil
+}
+
+var (
+	cleanupsMu sync.Mutex
+	cleanups   []func(ctx context.Context) error
+)
+
+func registerCleanup(f func(ctx context.Context) error) {
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	cleanups = append(cleanups, f)
+}
+
+// Cleanup signs out the op sessions and removes the op config directories and binaries created by
+// the op executors, it should be called when the provider stops.
+func Cleanup(ctx context.Context) error {
+	cleanupsMu.Lock()
+	fs := cleanups
+	cleanups = nil
+	cleanupsMu.Unlock()
+
+	// In reverse order, the op binary is needed to signout.
+	var errs []error
+	for i := len(fs) - 1; i >= 0; i-- {
+		errs = append(errs, fs[i](ctx))
+	}
+
+	return errors.Join(errs...)
 }
 
 // prepareOpCliBinary will prepare the op binary returning the path the execution must use.
 //
-// If running outside terraform cloud (tfe), we will require the op tool is available on
-// the system path as `op`.
+// If running outside terraform cloud (tfe) and not using the embedded op, we will require the op
+// tool is available on the system path as `op` (or on the custom path).
 //
-// If we are running in terraform cloud, then we will get our op binary from an embedded
-// file system, copy to a path and execute from there. We know that tfe uses linux amd64
-// machines.
-func prepareOpCliBinary(customBinPath string) (binPath string, err error) {
+// If we are running in terraform cloud or the embedded op is requested, then we will get our
+// op binary of the current platform from an embedded file system, verify and extract it
+// into a private temporary directory and execute from there.
+func prepareOpCliBinary(customBinPath string, useEmbedded bool) (binPath string, err error) {
 	const (
 		defaultBinPath   = "op"
-		tfeBinPath       = "/tmp/op-tfe"
 		tfeRunningEnvVar = "TFC_RUN_ID"
 	)
 
-	// If not terraform cloud, then regular execution.
+	// If not terraform cloud nor embedded, then regular execution.
 	tfe := os.Getenv(tfeRunningEnvVar)
-	if tfe == "" {
+	if tfe == "" && !useEmbedded {
 		// If custom binary path is empty, use the path default `op` one.
 		if customBinPath == "" {
 			return defaultBinPath, nil
@@ -80,30 +283,23 @@ func prepareOpCliBinary(customBinPath string) (binPath string, err error) {
 		return customBinPath, nil
 	}
 
-	// Copy embedded binary into a tmp file.
-	f, err := EmbeddedOpCli.ReadFile("op-cli-tfe/op")
-	if err != nil {
-		return "", fmt.Errorf("could not read embedded op cli: %w", err)
-	}
-
-	err = os.WriteFile(tfeBinPath, f, 0755)
-	if err != nil {
-		return "", fmt.Errorf("could not write embedded op cli into fs: %w", err)
-	}
-
-	return tfeBinPath, nil
+	return extractEmbeddedOpCli()
 }
 
-func (o opCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
-	if o.sessionToken == "" {
-		return "", "", fmt.Errorf("unauthenticated, op cli must singin first")
-	}
-
-	// Set session token and account before executing the command.
-	args = append([]string{"--session", o.sessionToken, "--account", "terraform"}, args...)
+// binOpCli executes op commands using the op binary.
+//
+// It doesn't handle authentication, the op session or credentials must be already set
+// on the command arguments or the environment.
+type binOpCli struct {
+	binPath string
+	// env is the environment of the op command, if nil the current process env will be used.
+	env []string
+}
 
+func (b binOpCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
 	// Prepare command and execute.
-	cmd := exec.CommandContext(ctx, o.binPath, args...)
+	cmd := exec.CommandContext(ctx, b.binPath, args...)
+	cmd.Env = b.env
 	var sout, serr bytes.Buffer
 	cmd.Stdout = &sout
 	cmd.Stderr = &serr
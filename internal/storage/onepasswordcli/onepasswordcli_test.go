@@ -0,0 +1,395 @@
+package onepasswordcli_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclitest"
+)
+
+// envVarOpCliCompatPath is the path of a real op binary used to check the op commands flags used by the
+// repository exist, CI sets it with the op version embedded on the releases.
+const envVarOpCliCompatPath = "OP_CLI_COMPAT_PATH"
+
+// assertOpCliFlags asserts the op command of the real op binary has the flags.
+func assertOpCliFlags(t *testing.T, cmd string, flags ...string) {
+	binPath := os.Getenv(envVarOpCliCompatPath)
+	if binPath == "" {
+		t.Skipf("%s env var is not set", envVarOpCliCompatPath)
+	}
+
+	out, err := exec.Command(binPath, append(strings.Fields(cmd), "--help")...).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	for _, flag := range flags {
+		// Flags on op help are like `      --guest   ...` or `  -f, --format string   ...`.
+		flagRegexp := regexp.MustCompile(`(?m)^\s+(-\w, )?` + regexp.QuoteMeta(flag) + `(\s|$)`)
+		assert.Regexp(t, flagRegexp, string(out), "op %q doesn't have %q flag", cmd, flag)
+	}
+}
+
+func TestNewOpCliIsolatedAccounts(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	op := onepasswordclitest.NewOpBinary(t, "2.30.0")
+
+	// Create two op executors like two provider aliases would do.
+	cli0, _, err := onepasswordcli.NewOpCli(op.Path, false, "test0.1password.com", "test0@slok.dev", "secret0", "password0")
+	require.NoError(err)
+	cli1, _, err := onepasswordcli.NewOpCli(op.Path, false, "test1.1password.com", "test1@slok.dev", "secret1", "password1")
+	require.NoError(err)
+
+	_, _, err = cli0.RunOpCmd(context.TODO(), []string{"user", "list"})
+	require.NoError(err)
+	_, _, err = cli1.RunOpCmd(context.TODO(), []string{"user", "list"})
+	require.NoError(err)
+
+	err = onepasswordcli.Cleanup(context.TODO())
+	require.NoError(err)
+
+	// Check the executions.
+	execs := op.Calls(t)
+	require.Len(execs, 8)
+
+	// Account registrations and health checks.
+	add0, add1 := execs[0], execs[2]
+	assert.Equal([]string{"account", "add", "--address", "test0.1password.com", "--email", "test0@slok.dev", "--secret-key", "secret0", "--shorthand"}, add0.Args[:9])
+	assert.Equal([]string{"account", "add", "--address", "test1.1password.com", "--email", "test1@slok.dev", "--secret-key", "secret1", "--shorthand"}, add1.Args[:9])
+	account0, account1 := add0.Args[9], add1.Args[9]
+	assert.True(strings.HasPrefix(account0, "terraform-"))
+	assert.NotEqual(account0, account1, "accounts should have different shorthands")
+	assert.NotEqual(add0.ConfigDir, add1.ConfigDir, "accounts should have different op config dirs")
+
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add0.ConfigDir, Args: []string{"--session", "token-0", "--account", account0, "whoami", "--format", "json"}}, execs[1])
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add1.ConfigDir, Args: []string{"--session", "token-0", "--account", account1, "whoami", "--format", "json"}}, execs[3])
+
+	// Commands.
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add0.ConfigDir, Args: []string{"--session", "token-0", "--account", account0, "user", "list"}}, execs[4])
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add1.ConfigDir, Args: []string{"--session", "token-0", "--account", account1, "user", "list"}}, execs[5])
+
+	// Cleanup (in reverse order).
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add1.ConfigDir, Args: []string{"--session", "token-0", "--account", account1, "signout"}}, execs[6])
+	assert.Equal(onepasswordclitest.OpCall{ConfigDir: add0.ConfigDir, Args: []string{"--session", "token-0", "--account", account0, "signout"}}, execs[7])
+	for _, dir := range []string{add0.ConfigDir, add1.ConfigDir} {
+		_, err := os.Stat(dir)
+		assert.True(os.IsNotExist(err), "op config dir should be removed")
+	}
+}
+
+func TestNewOpCliInfo(t *testing.T) {
+	tests := map[string]struct {
+		version        string
+		serviceAccount bool
+		expInfo        *onepasswordcli.OpInfo
+		expErr         bool
+	}{
+		"A supported op version, should return the op information.": {
+			version: "2.30.0",
+			expInfo: &onepasswordcli.OpInfo{
+				Version:     "2.30.0",
+				AccountURL:  "https://test.1password.com",
+				AccountUUID: "account-00",
+				UserUUID:    "user-00",
+				UserEmail:   "test@slok.dev",
+				UserType:    "HUMAN",
+			},
+		},
+
+		"An op v1 version, should fail.": {
+			version: "1.12.4",
+			expErr:  true,
+		},
+
+		"An invalid op version, should fail.": {
+			version: "something",
+			expErr:  true,
+		},
+
+		"A supported op version with service account, should return the op information.": {
+			version:        "2.18.0",
+			serviceAccount: true,
+			expInfo: &onepasswordcli.OpInfo{
+				Version:     "2.18.0",
+				AccountURL:  "https://test.1password.com",
+				AccountUUID: "account-00",
+				UserUUID:    "user-00",
+				UserType:    "SERVICE_ACCOUNT",
+			},
+		},
+
+		"An op version without service account support with service account, should fail.": {
+			version:        "2.17.0",
+			serviceAccount: true,
+			expErr:         true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			op := onepasswordclitest.NewOpBinary(t, test.version)
+			t.Cleanup(func() { _ = onepasswordcli.Cleanup(context.TODO()) })
+
+			var gotInfo *onepasswordcli.OpInfo
+			var err error
+			if test.serviceAccount {
+				_, gotInfo, err = onepasswordcli.NewServiceAccountOpCli(op.Path, false, "token-00")
+			} else {
+				_, gotInfo, err = onepasswordcli.NewOpCli(op.Path, false, "test.1password.com", "test@slok.dev", "secret", "password")
+			}
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expInfo, gotInfo)
+			}
+		})
+	}
+}
+
+func TestNewOpCliEmbedded(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake op binary is a shell script")
+	}
+
+	platformBin := runtime.GOOS + "_" + runtime.GOARCH + "/op"
+
+	tests := map[string]struct {
+		embedded func(bin []byte) fstest.MapFS
+		expErr   bool
+	}{
+		"Using the embedded op of the platform with a correct checksum, should extract and execute it.": {
+			embedded: func(bin []byte) fstest.MapFS {
+				sum := sha256.Sum256(bin)
+				return fstest.MapFS{
+					"op-cli-embedded/" + platformBin: {Data: bin},
+					"op-cli-embedded/SHA256SUMS":     {Data: []byte(hex.EncodeToString(sum[:]) + "  " + platformBin + "\n")},
+				}
+			},
+		},
+
+		"Using the embedded op with a wrong checksum, should fail.": {
+			embedded: func(bin []byte) fstest.MapFS {
+				sum := sha256.Sum256([]byte("something"))
+				return fstest.MapFS{
+					"op-cli-embedded/" + platformBin: {Data: bin},
+					"op-cli-embedded/SHA256SUMS":     {Data: []byte(hex.EncodeToString(sum[:]) + "  " + platformBin + "\n")},
+				}
+			},
+			expErr: true,
+		},
+
+		"Using the embedded op without checksum, should fail.": {
+			embedded: func(bin []byte) fstest.MapFS {
+				sum := sha256.Sum256(bin)
+				return fstest.MapFS{
+					"op-cli-embedded/" + platformBin: {Data: bin},
+					"op-cli-embedded/SHA256SUMS":     {Data: []byte(hex.EncodeToString(sum[:]) + "  other_arch/op\n")},
+				}
+			},
+			expErr: true,
+		},
+
+		"Using the embedded op without the platform binary, should fail.": {
+			embedded: func(bin []byte) fstest.MapFS {
+				sum := sha256.Sum256(bin)
+				return fstest.MapFS{
+					"op-cli-embedded/other_arch/op": {Data: bin},
+					"op-cli-embedded/SHA256SUMS":    {Data: []byte(hex.EncodeToString(sum[:]) + "  other_arch/op\n")},
+				}
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			// Fake op that logs its path.
+			logPath := filepath.Join(t.TempDir(), "calls.log")
+			bin := []byte(`#!/bin/sh
+echo "$0" >> "` + logPath + `"
+case "$1" in
+--version) echo "2.30.0" ;;
+whoami) echo '{"url":"https://test.1password.com","user_uuid":"user-00","account_uuid":"account-00","user_type":"SERVICE_ACCOUNT"}' ;;
+esac
+`)
+
+			oldEmbedded := onepasswordcli.EmbeddedOpCli
+			onepasswordcli.EmbeddedOpCli = test.embedded(bin)
+			defer func() { onepasswordcli.EmbeddedOpCli = oldEmbedded }()
+
+			_, _, err := onepasswordcli.NewServiceAccountOpCli("", true, "token-00")
+
+			if test.expErr {
+				assert.Error(err)
+				_, err := os.Stat(logPath)
+				assert.True(os.IsNotExist(err), "op should not be executed")
+				return
+			}
+			require.NoError(err)
+
+			// Check the extracted binary is private.
+			logData, err := os.ReadFile(logPath)
+			require.NoError(err)
+			binPath := strings.Split(strings.TrimSpace(string(logData)), "\n")[0]
+			info, err := os.Stat(filepath.Dir(binPath))
+			require.NoError(err)
+			assert.Equal(fs.FileMode(0700), info.Mode().Perm())
+			info, err = os.Stat(binPath)
+			require.NoError(err)
+			assert.Equal(fs.FileMode(0700), info.Mode().Perm())
+
+			// Check the binary is removed on cleanup.
+			err = onepasswordcli.Cleanup(context.TODO())
+			require.NoError(err)
+			_, err = os.Stat(filepath.Dir(binPath))
+			assert.True(os.IsNotExist(err), "embedded op dir should be removed")
+		})
+	}
+}
+
+func TestCheckEmbeddedOpCliPlatform(t *testing.T) {
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	tests := map[string]struct {
+		embedded     fstest.MapFS
+		expPlatforms []string
+		expErr       bool
+	}{
+		"Having the op of the platform embedded, should not fail.": {
+			embedded: fstest.MapFS{
+				"op-cli-embedded/" + platform + "/op": {Data: []byte("op")},
+				"op-cli-embedded/other_arch/op":       {Data: []byte("op")},
+				"op-cli-embedded/SHA256SUMS":          {Data: []byte("")},
+			},
+			expPlatforms: []string{platform, "other_arch"},
+		},
+
+		"Not having the op of the platform embedded, should fail.": {
+			embedded: fstest.MapFS{
+				"op-cli-embedded/other_arch/op": {Data: []byte("op")},
+				"op-cli-embedded/SHA256SUMS":    {Data: []byte("")},
+			},
+			expPlatforms: []string{"other_arch"},
+			expErr:       true,
+		},
+
+		"Not having any op embedded (e.g: development builds), should fail.": {
+			embedded: fstest.MapFS{
+				"op-cli-embedded/.gitkeep": {Data: []byte("")},
+			},
+			expPlatforms: []string{},
+			expErr:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			oldEmbedded := onepasswordcli.EmbeddedOpCli
+			onepasswordcli.EmbeddedOpCli = test.embedded
+			defer func() { onepasswordcli.EmbeddedOpCli = oldEmbedded }()
+
+			gotPlatforms, err := onepasswordcli.EmbeddedOpCliPlatforms()
+			require.NoError(err)
+			assert.ElementsMatch(test.expPlatforms, gotPlatforms)
+
+			err = onepasswordcli.CheckEmbeddedOpCliPlatform()
+			if test.expErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestNewExistingSessionOpCli(t *testing.T) {
+	tests := map[string]struct {
+		account      string
+		sessionToken string
+		expCmds      []string
+		expErr       bool
+	}{
+		"Using an existing session token, should execute the commands with the session and account.": {
+			account:      "my",
+			sessionToken: "token-00",
+			expCmds: []string{
+				"--session token-00 --account my whoami --format json",
+				"--session token-00 --account my user list",
+			},
+		},
+
+		"Using an existing account without session token, should execute the commands with the account.": {
+			account: "my",
+			expCmds: []string{
+				"--account my whoami --format json",
+				"--account my user list",
+			},
+		},
+
+		"Using the existing default account, should execute the commands as they are.": {
+			expCmds: []string{
+				"whoami --format json",
+				"user list",
+			},
+		},
+
+		"Using a session token without account, should fail.": {
+			sessionToken: "token-00",
+			expErr:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			// The existing session uses the user op config.
+			t.Setenv("OP_CONFIG_DIR", "")
+			op := onepasswordclitest.NewOpBinary(t, "2.30.0")
+
+			cli, _, err := onepasswordcli.NewExistingSessionOpCli(op.Path, false, test.account, test.sessionToken)
+			if test.expErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			_, _, err = cli.RunOpCmd(context.TODO(), []string{"user", "list"})
+			require.NoError(err)
+
+			// The existing session should not be signed out.
+			err = onepasswordcli.Cleanup(context.TODO())
+			require.NoError(err)
+
+			gotCmds := []string{}
+			for _, c := range op.Calls(t) {
+				gotCmds = append(gotCmds, strings.Join(c.Args, " "))
+			}
+			assert.Equal(test.expCmds, gotCmds)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package onepasswordcli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	//go:embed op-cli-embedded/*
+	embeddedOpCli embed.FS
+
+	// EmbeddedOpCli are the embedded 1password CLIs.
+	//
+	// By default some environments don't have op CLI (e.g: Terraform cloud). We embed the op CLI
+	// of multiple platforms so it can be used when the app runs. The binaries are stored by
+	// platform as `{GOOS}_{GOARCH}/op` (`op.exe` on windows), and the `SHA256SUMS` manifest has
+	// the checksums of all of them (in `sha256sum` format), the binary will be verified against it
+	// before being used.
+	//
+	// Note: We are embedding all the platform binaries in all architecture builds.
+	// This will increase the size of all architecture binaries although only one of them
+	// can be used. We assume that tradeoff in favor of compiling simplicity.
+	EmbeddedOpCli fs.FS = embeddedOpCli
+)
+
+const (
+	embeddedOpCliDir      = "op-cli-embedded"
+	embeddedOpCliManifest = "SHA256SUMS"
+)
+
+var (
+	embeddedOpCliMu   sync.Mutex
+	embeddedOpCliPath string
+)
+
+// EmbeddedOpCliPlatforms returns the platforms (`{GOOS}_{GOARCH}`) that have an embedded op CLI.
+func EmbeddedOpCliPlatforms() ([]string, error) {
+	entries, err := fs.ReadDir(EmbeddedOpCli, embeddedOpCliDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read embedded op clis: %w", err)
+	}
+
+	platforms := []string{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		for _, binName := range []string{"op", "op.exe"} {
+			if _, err := fs.Stat(EmbeddedOpCli, path.Join(embeddedOpCliDir, e.Name(), binName)); err == nil {
+				platforms = append(platforms, e.Name())
+				break
+			}
+		}
+	}
+
+	return platforms, nil
+}
+
+// CheckEmbeddedOpCliPlatform returns an error if the current platform doesn't have an embedded op CLI.
+func CheckEmbeddedOpCliPlatform() error {
+	platforms, err := EmbeddedOpCliPlatforms()
+	if err != nil {
+		return err
+	}
+
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	if slices.Contains(platforms, platform) {
+		return nil
+	}
+
+	embedded := "none"
+	if len(platforms) > 0 {
+		embedded = strings.Join(platforms, ", ")
+	}
+
+	return fmt.Errorf("there is no embedded op cli for %s platform (embedded platforms: %s)", platform, embedded)
+}
+
+// extractEmbeddedOpCli extracts the embedded op binary of the current platform into a
+// private temporary directory (only once per process) and returns its path.
+//
+// The binary is verified with the embedded checksums manifest before being extracted.
+func extractEmbeddedOpCli() (string, error) {
+	embeddedOpCliMu.Lock()
+	defer embeddedOpCliMu.Unlock()
+
+	if embeddedOpCliPath != "" {
+		return embeddedOpCliPath, nil
+	}
+
+	binName := "op"
+	if runtime.GOOS == "windows" {
+		binName = "op.exe"
+	}
+	binFile := path.Join(runtime.GOOS+"_"+runtime.GOARCH, binName)
+
+	bin, err := fs.ReadFile(EmbeddedOpCli, path.Join(embeddedOpCliDir, binFile))
+	if err != nil {
+		return "", fmt.Errorf("could not read embedded op cli for %s/%s: %w", runtime.GOOS, runtime.GOARCH, err)
+	}
+
+	err = verifyEmbeddedOpCli(binFile, bin)
+	if err != nil {
+		return "", err
+	}
+
+	// The temp dir is only accessible by the user.
+	dir, err := os.MkdirTemp("", "terraform-provider-onepasswordorg-bin-")
+	if err != nil {
+		return "", fmt.Errorf("could not create embedded op cli dir: %w", err)
+	}
+
+	binPath := filepath.Join(dir, binName)
+	err = os.WriteFile(binPath, bin, 0700)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("could not write embedded op cli into fs: %w", err)
+	}
+
+	registerCleanup(func(ctx context.Context) error {
+		embeddedOpCliMu.Lock()
+		defer embeddedOpCliMu.Unlock()
+
+		embeddedOpCliPath = ""
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("could not remove embedded op cli dir: %w", err)
+		}
+		return nil
+	})
+	embeddedOpCliPath = binPath
+
+	return binPath, nil
+}
+
+// verifyEmbeddedOpCli checks the binary checksum matches the one on the embedded checksums manifest.
+func verifyEmbeddedOpCli(binFile string, bin []byte) error {
+	manifest, err := fs.ReadFile(EmbeddedOpCli, path.Join(embeddedOpCliDir, embeddedOpCliManifest))
+	if err != nil {
+		return fmt.Errorf("could not read embedded op cli checksums: %w", err)
+	}
+
+	// Manifest lines are `{checksum}  {file}`.
+	var expChecksum string
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == binFile {
+			expChecksum = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read embedded op cli checksums: %w", err)
+	}
+
+	if expChecksum == "" {
+		return fmt.Errorf("missing embedded op cli %q checksum", binFile)
+	}
+
+	sum := sha256.Sum256(bin)
+	gotChecksum := hex.EncodeToString(sum[:])
+	if gotChecksum != expChecksum {
+		return fmt.Errorf("embedded op cli %q checksum mismatch, expected %s, got %s", binFile, expChecksum, gotChecksum)
+	}
+
+	return nil
+}
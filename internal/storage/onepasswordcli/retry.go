@@ -0,0 +1,115 @@
+package onepasswordcli
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryOpCliConfig is the configuration of NewRetryOpCli.
+type RetryOpCliConfig struct {
+	// MaxRetries is the maximum number of times a failed command will be retried, 0 disables retries.
+	MaxRetries int
+	// MaxWait is the maximum time spent retrying a command, when reached it will not retry
+	// anymore, 0 means no limit.
+	MaxWait time.Duration
+	// BaseBackoff is the wait before the first retry, it will be doubled on every retry.
+	BaseBackoff time.Duration
+	// MaxBackoff is the maximum wait between retries.
+	MaxBackoff time.Duration
+}
+
+func (c *RetryOpCliConfig) defaults() error {
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max retries can't be negative")
+	}
+
+	if c.MaxWait < 0 {
+		return fmt.Errorf("max wait can't be negative")
+	}
+
+	if c.BaseBackoff == 0 {
+		c.BaseBackoff = 500 * time.Millisecond
+	}
+
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = 30 * time.Second
+	}
+
+	return nil
+}
+
+// NewRetryOpCli returns an OpCli that retries the commands that failed with a retryable error
+// (e.g: 1password API throttling), using a jittered exponential backoff between retries.
+func NewRetryOpCli(cli OpCli, config RetryOpCliConfig) (OpCli, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return retryOpCli{
+		cli: cli,
+		cfg: config,
+	}, nil
+}
+
+type retryOpCli struct {
+	cli OpCli
+	cfg RetryOpCliConfig
+}
+
+func (r retryOpCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err = r.cli.RunOpCmd(ctx, args)
+		if err == nil || !isOpRetryableError(ctx, stderr) || attempt >= r.cfg.MaxRetries {
+			return stdout, stderr, err
+		}
+
+		wait := r.backoff(attempt)
+		if r.cfg.MaxWait > 0 && time.Since(start)+wait > r.cfg.MaxWait {
+			tflog.Debug(ctx, "op command retry max wait reached, not retrying", map[string]interface{}{
+				"attempt":  attempt + 1,
+				"max_wait": r.cfg.MaxWait.String(),
+			})
+			return stdout, stderr, err
+		}
+
+		tflog.Warn(ctx, "op command failed with a retryable error, retrying", map[string]interface{}{
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"stderr":  stderr,
+		})
+
+		select {
+		case <-ctx.Done():
+			return stdout, stderr, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoff returns the wait before the retry, it will be a random duration between
+// half and the full exponential backoff of the attempt, so parallel commands that failed at the
+// same time don't retry at the same time.
+func (r retryOpCli) backoff(attempt int) time.Duration {
+	backoff := r.cfg.MaxBackoff
+	if attempt < 32 && r.cfg.BaseBackoff<<attempt < r.cfg.MaxBackoff {
+		backoff = r.cfg.BaseBackoff << attempt
+	}
+
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+func isOpRetryableError(ctx context.Context, stderr string) bool {
+	// Don't retry if the command was cancelled.
+	if ctx.Err() != nil {
+		return false
+	}
+
+	return opRetryableRegexp.MatchString(stderr)
+}
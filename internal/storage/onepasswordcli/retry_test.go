@@ -0,0 +1,121 @@
+package onepasswordcli_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
+)
+
+func TestRetryOpCliRunOpCmd(t *testing.T) {
+	const (
+		expCmd           = `group user grant --user user-00 --group group-00`
+		throttlingStderr = `[ERROR] 2022/03/14 17:13:39 Too many requests. Please wait a while before trying again.`
+	)
+
+	tests := map[string]struct {
+		config    onepasswordcli.RetryOpCliConfig
+		mock      func(m *onepasswordclimock.OpCli)
+		expStdout string
+		expErr    bool
+	}{
+		"A command without errors, should not be retried.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("ok", "", nil)
+			},
+			expStdout: "ok",
+		},
+
+		"A command with a non retryable error, should not be retried.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				stderr := `[ERROR] 2022/03/14 17:13:39 "user-00" isn't a user in this account.`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+
+		"A command with a temporary non throttling error, should not be retried.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				stderr := `[ERROR] 2022/03/14 17:13:39 Post "https://my.1password.com/api/v2/group/user": net/http: TLS handshake timeout`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+
+		"A command with a server error, should not be retried.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				stderr := `[ERROR] 2022/03/14 17:13:39 (502) Bad Gateway`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+
+		"A command with a retryable error, should be retried until it succeeds.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Twice().Return("", throttlingStderr, fmt.Errorf("exit status 1"))
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("ok", "", nil)
+			},
+			expStdout: "ok",
+		},
+
+		"A command with a retryable error, should fail after the max retries.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Times(4).Return("", throttlingStderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+
+		"A command with a retryable error and disabled retries, should not be retried.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 0, BaseBackoff: time.Millisecond},
+			mock: func(m *onepasswordclimock.OpCli) {
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", throttlingStderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+
+		"A command with a retryable error, should not be retried if the backoff exceeds the max wait.": {
+			config: onepasswordcli.RetryOpCliConfig{MaxRetries: 3, BaseBackoff: time.Hour, MaxBackoff: time.Hour, MaxWait: time.Minute},
+			mock: func(m *onepasswordclimock.OpCli) {
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", throttlingStderr, fmt.Errorf("exit status 1"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			cli, err := onepasswordcli.NewRetryOpCli(mc, test.config)
+			require.NoError(err)
+
+			gotStdout, _, err := cli.RunOpCmd(context.TODO(), strings.Fields(expCmd))
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expStdout, gotStdout)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
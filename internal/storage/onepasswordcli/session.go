@@ -0,0 +1,125 @@
+package onepasswordcli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SigninFunc signs in on 1password and returns the op session token.
+type SigninFunc func(ctx context.Context) (sessionToken string, err error)
+
+// NewSessionOpCli returns an OpCli that executes the commands with the wrapped OpCli authenticated
+// with an op session of the account.
+//
+// op sessions expire after 30 minutes of inactivity, so if a command fails because the session
+// expired, it will signin again and retry the command once.
+func NewSessionOpCli(cli OpCli, account string, signin SigninFunc) (OpCli, error) {
+	return newSessionOpCli(cli, account, signin)
+}
+
+func newSessionOpCli(cli OpCli, account string, signin SigninFunc) (*sessionOpCli, error) {
+	sessionToken, err := signin(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("cannot signin: %w", err)
+	}
+
+	return &sessionOpCli{
+		cli:          cli,
+		account:      account,
+		signin:       signin,
+		sessionToken: sessionToken,
+	}, nil
+}
+
+type sessionOpCli struct {
+	cli          OpCli
+	account      string
+	signin       SigninFunc
+	sessionToken string
+	mu           sync.RWMutex
+}
+
+func (s *sessionOpCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
+	sessionToken := s.getSessionToken()
+	stdout, stderr, err = s.runOpCmd(ctx, sessionToken, args)
+	if err == nil || !opSessionExpiredRegexp.MatchString(stderr) {
+		return stdout, stderr, err
+	}
+
+	// Session expired, signin again and retry.
+	sessionToken, err = s.refreshSessionToken(ctx, sessionToken)
+	if err != nil {
+		return "", "", fmt.Errorf("could not signin again after op session expired: %w", err)
+	}
+
+	return s.runOpCmd(ctx, sessionToken, args)
+}
+
+func (s *sessionOpCli) runOpCmd(ctx context.Context, sessionToken string, args []string) (stdout, stderr string, err error) {
+	// Set session token and account before executing the command.
+	args = append([]string{"--session", sessionToken, "--account", s.account}, args...)
+	return s.cli.RunOpCmd(ctx, args)
+}
+
+// signout signs out the current op session.
+func (s *sessionOpCli) signout(ctx context.Context) error {
+	_, stderr, err := s.runOpCmd(ctx, s.getSessionToken(), []string{"signout"})
+	if err != nil {
+		return fmt.Errorf("could not signout: %w: %s", err, stderr)
+	}
+
+	return nil
+}
+
+func (s *sessionOpCli) getSessionToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sessionToken
+}
+
+// refreshSessionToken will signin again to replace the expired session token. If the session has
+// already been refreshed by another command in the meantime, it will reuse the new one.
+func (s *sessionOpCli) refreshSessionToken(ctx context.Context, expiredSessionToken string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sessionToken != expiredSessionToken {
+		return s.sessionToken, nil
+	}
+
+	sessionToken, err := s.signin(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.sessionToken = sessionToken
+
+	return sessionToken, nil
+}
+
+// newExistingSessionSigninFunc returns a signin func that returns an already signed in op session,
+// it can't signin again so once the session expires it will fail.
+func newExistingSessionSigninFunc(sessionToken string) SigninFunc {
+	used := false
+	return func(ctx context.Context) (string, error) {
+		if used {
+			return "", fmt.Errorf("the existing op session expired, signin again with op")
+		}
+		used = true
+
+		return sessionToken, nil
+	}
+}
+
+// accountOpCli executes the commands with the wrapped OpCli on the account, letting op use its
+// own session of the account (e.g: op desktop app integration, `OP_SESSION_*` env vars).
+type accountOpCli struct {
+	cli     OpCli
+	account string
+}
+
+func (a accountOpCli) RunOpCmd(ctx context.Context, args []string) (stdout, stderr string, err error) {
+	args = append([]string{"--account", a.account}, args...)
+	return a.cli.RunOpCmd(ctx, args)
+}
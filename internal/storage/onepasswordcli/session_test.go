@@ -0,0 +1,128 @@
+package onepasswordcli_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
+)
+
+func TestSessionOpCliRunOpCmd(t *testing.T) {
+	const expiredStderr = `[ERROR] 2022/03/14 17:13:39 You are not currently signed in. Please run ` + "`op signin --help`" + ` for instructions`
+
+	tests := map[string]struct {
+		signinTokens []string
+		signinErrs   []error
+		mock         func(m *onepasswordclimock.OpCli)
+		expStdout    string
+		expSignins   int
+		expErr       bool
+	}{
+		"Running a command should execute it with the session and account.": {
+			signinTokens: []string{"token-0"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `--session token-0 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("{}", "", nil)
+			},
+			expStdout:  "{}",
+			expSignins: 1,
+		},
+
+		"Having a regular error while running the command, should fail without signing in again.": {
+			signinTokens: []string{"token-0"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `--session token-0 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "something", fmt.Errorf("exit status 1"))
+			},
+			expSignins: 1,
+			expErr:     true,
+		},
+
+		"Having an expired session, should signin again and retry the command with the new session.": {
+			signinTokens: []string{"token-0", "token-1"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `--session token-0 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", expiredStderr, fmt.Errorf("exit status 1"))
+
+				expCmd = `--session token-1 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("{}", "", nil)
+			},
+			expStdout:  "{}",
+			expSignins: 2,
+		},
+
+		"Having an expired session after signing in again, should only retry once.": {
+			signinTokens: []string{"token-0", "token-1"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `--session token-0 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", expiredStderr, fmt.Errorf("exit status 1"))
+
+				expCmd = `--session token-1 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", expiredStderr, fmt.Errorf("exit status 1"))
+			},
+			expSignins: 2,
+			expErr:     true,
+		},
+
+		"Having an error while signing in again, should fail.": {
+			signinTokens: []string{"token-0", ""},
+			signinErrs:   []error{nil, fmt.Errorf("something")},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `--session token-0 --account terraform user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", expiredStderr, fmt.Errorf("exit status 1"))
+			},
+			expSignins: 2,
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			signins := 0
+			signin := func(ctx context.Context) (string, error) {
+				i := signins
+				signins++
+				if i < len(test.signinErrs) && test.signinErrs[i] != nil {
+					return "", test.signinErrs[i]
+				}
+				return test.signinTokens[i], nil
+			}
+
+			cli, err := onepasswordcli.NewSessionOpCli(mc, "terraform", signin)
+			require.NoError(err)
+
+			gotStdout, _, err := cli.RunOpCmd(context.TODO(), strings.Fields("user get test-id --format json"))
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expStdout, gotStdout)
+			}
+			assert.Equal(test.expSignins, signins)
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewSessionOpCliSigninError(t *testing.T) {
+	mc := &onepasswordclimock.OpCli{}
+	signin := func(ctx context.Context) (string, error) { return "", fmt.Errorf("something") }
+
+	_, err := onepasswordcli.NewSessionOpCli(mc, "terraform", signin)
+
+	assert.Error(t, err)
+}
@@ -4,17 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
 func (r Repository) CreateUser(ctx context.Context, user model.User) (*model.User, error) {
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.UserArg().ProvisionArg().EmailFlag(user.Email).NameFlag(user.Name).FormatJSONFlag()
+	cmdArgs.UserArg().ProvisionArg().EmailFlag(user.Email).NameFlag(user.Name).GuestFlag(user.Type == model.UserTypeGuest).FormatJSONFlag()
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ou := opUser{}
@@ -34,7 +36,7 @@ func (r Repository) GetUserByID(ctx context.Context, id string) (*model.User, er
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ou := opUser{}
@@ -54,7 +56,7 @@ func (r Repository) GetUserByEmail(ctx context.Context, email string) (*model.Us
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ou := opUser{}
@@ -69,15 +71,26 @@ func (r Repository) GetUserByEmail(ctx context.Context, email string) (*model.Us
 }
 
 func (r Repository) EnsureUser(ctx context.Context, user model.User) (*model.User, error) {
+	// op can't change the email of the users, only they can change it from their account.
+	current, err := r.GetUserByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get current user: %w", err)
+	}
+
+	if current.Email != user.Email {
+		return nil, fmt.Errorf("changing user email from %q to %q: %w", current.Email, user.Email, storage.ErrNotSupported)
+	}
+
 	cmdArgs := &onePasswordCliCmd{}
 	cmdArgs.UserArg().EditArg().RawStrArg(user.ID).NameFlag(user.Name)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
-	return &user, nil
+	// Edit doesn't return the user, get it to return the data that is not set by us (e.g: type, dates).
+	return r.GetUserByID(ctx, user.ID)
 }
 
 func (r Repository) DeleteUser(ctx context.Context, id string) error {
@@ -86,22 +99,113 @@ func (r Repository) DeleteUser(ctx context.Context, id string) error {
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
+	}
+
+	return nil
+}
+
+func (r Repository) SuspendUser(ctx context.Context, id string) error {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.UserArg().SuspendArg().RawStrArg(id)
+
+	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return newOpCmdError(err, stderr)
+	}
+
+	return nil
+}
+
+func (r Repository) ReactivateUser(ctx context.Context, id string) error {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.UserArg().ReactivateArg().RawStrArg(id)
+
+	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
+func (r Repository) ListUsers(ctx context.Context) ([]model.User, error) {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.UserArg().ListArg().FormatJSONFlag()
+
+	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return nil, newOpCmdError(err, stderr)
+	}
+
+	ous := []opUser{}
+	err = json.Unmarshal([]byte(stdout), &ous)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	users := make([]model.User, 0, len(ous))
+	for _, ou := range ous {
+		users = append(users, mapOpToModelUser(ou))
+	}
+
+	return users, nil
+}
+
 type opUser struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+const (
+	opUserTypeMember = "MEMBER"
+	opUserTypeGuest  = "GUEST"
+)
+
+const (
+	opUserStateActive          = "ACTIVE"
+	opUserStateSuspended       = "SUSPENDED"
+	opUserStateTransferPending = "TRANSFER_PENDING"
+	opUserStateTransferStarted = "TRANSFER_STARTED"
+)
+
 func mapOpToModelUser(u opUser) model.User {
 	return model.User{
-		ID:    u.ID,
-		Email: u.Email,
-		Name:  u.Name,
+		ID:        u.ID,
+		Email:     u.Email,
+		Name:      u.Name,
+		Type:      mapOpToModelUserType(u.Type),
+		State:     mapOpToModelUserState(u.State),
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+func mapOpToModelUserType(t string) model.UserType {
+	switch t {
+	case opUserTypeMember:
+		return model.UserTypeMember
+	case opUserTypeGuest:
+		return model.UserTypeGuest
+	default:
+		return model.UserTypeUnknown
+	}
+}
+
+func mapOpToModelUserState(state string) model.UserState {
+	switch state {
+	case opUserStateActive:
+		return model.UserStateActive
+	case opUserStateSuspended:
+		return model.UserStateSuspended
+	case opUserStateTransferPending, opUserStateTransferStarted:
+		return model.UserStatePending
+	default:
+		return model.UserStateUnknown
 	}
 }
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -26,7 +28,7 @@ func TestRepositoryCreateUser(t *testing.T) {
 			user: model.User{Email: "test@test.io", Name: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
 				expCmd := `user provision --email test@test.io --name Test00 --format json`
-				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00"}`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"MEMBER","state":"ACTIVE"}`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
 			expUser: &model.User{
@@ -36,6 +38,21 @@ func TestRepositoryCreateUser(t *testing.T) {
 			},
 		},
 
+		"Creating a guest user correctly, should provision the user as a guest.": {
+			user: model.User{Email: "test@test.io", Name: "Test00", Type: model.UserTypeGuest},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user provision --email test@test.io --name Test00 --guest --format json`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"GUEST","state":"ACTIVE"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUser: &model.User{
+				ID:    "1234567890",
+				Email: "test@test.io",
+				Name:  "Test00",
+				Type:  model.UserTypeGuest,
+			},
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			user: model.User{Email: "test@test.io", Name: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
@@ -70,18 +87,23 @@ func TestRepositoryCreateUser(t *testing.T) {
 	}
 }
 
+func TestOpCliUserProvisionFlags(t *testing.T) {
+	assertOpCliFlags(t, "user provision", "--email", "--name", "--guest", "--format")
+}
+
 func TestRepositoryGetUserByID(t *testing.T) {
 	tests := map[string]struct {
-		id      string
-		mock    func(m *onepasswordclimock.OpCli)
-		expUser *model.User
-		expErr  bool
+		id          string
+		mock        func(m *onepasswordclimock.OpCli)
+		expUser     *model.User
+		expErr      bool
+		expNotFound bool
 	}{
 		"Getting a user correctly, should return the user data.": {
 			id: "test-id",
 			mock: func(m *onepasswordclimock.OpCli) {
 				expCmd := `user get test-id --format json`
-				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00"}`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"MEMBER","state":"ACTIVE"}`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
 			expUser: &model.User{
@@ -91,6 +113,64 @@ func TestRepositoryGetUserByID(t *testing.T) {
 			},
 		},
 
+		"Getting a guest user, should return the user data with the type and dates.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"GUEST","state":"ACTIVE","created_at":"2022-03-14T17:13:39Z","updated_at":"2022-03-15T09:00:00Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUser: &model.User{
+				ID:        "1234567890",
+				Email:     "test@test.io",
+				Name:      "Test00",
+				Type:      model.UserTypeGuest,
+				CreatedAt: time.Date(2022, 3, 14, 17, 13, 39, 0, time.UTC),
+				UpdatedAt: time.Date(2022, 3, 15, 9, 0, 0, 0, time.UTC),
+			},
+		},
+
+		"Getting a suspended user, should return the user data with the suspended state.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"MEMBER","state":"SUSPENDED"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUser: &model.User{
+				ID:    "1234567890",
+				Email: "test@test.io",
+				Name:  "Test00",
+				State: model.UserStateSuspended,
+			},
+		},
+
+		"Getting an invited user, should return the user data with the pending state.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"MEMBER","state":"TRANSFER_PENDING"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUser: &model.User{
+				ID:    "1234567890",
+				Email: "test@test.io",
+				Name:  "Test00",
+				State: model.UserStatePending,
+			},
+		},
+
+		"Getting a missing user, should fail with a not found error.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				stderr := `[ERROR] 2022/03/14 17:13:39 "test-id" isn't a user in this account.`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr:      true,
+			expNotFound: true,
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			id: "test-id",
 			mock: func(m *onepasswordclimock.OpCli) {
@@ -116,6 +196,9 @@ func TestRepositoryGetUserByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expUser, gotUser)
 			}
@@ -136,7 +219,7 @@ func TestRepositoryGetUserByEmail(t *testing.T) {
 			email: "test@test.io",
 			mock: func(m *onepasswordclimock.OpCli) {
 				expCmd := `user get test@test.io --format json`
-				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00"}`
+				stdout := `{"id":"1234567890","email":"test@test.io","name":"Test00","type":"MEMBER","state":"ACTIVE"}`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
 			expUser: &model.User{
@@ -182,24 +265,63 @@ func TestRepositoryGetUserByEmail(t *testing.T) {
 
 func TestRepositoryEnsureUser(t *testing.T) {
 	tests := map[string]struct {
-		user    model.User
-		mock    func(m *onepasswordclimock.OpCli)
-		expUser *model.User
-		expErr  bool
+		user               model.User
+		mock               func(m *onepasswordclimock.OpCli)
+		expUser            *model.User
+		expErr             bool
+		expErrNotSupported bool
 	}{
-		"Updating a user correctly, should update the user data.": {
+		"Updating a user correctly, should update the user data and return the updated user.": {
 			user: model.User{ID: "test-id", Email: "test@test.io", Name: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `user edit test-id --name Test00`
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"test-id","email":"test@test.io","name":"Test","type":"GUEST","state":"ACTIVE","created_at":"2022-03-14T17:13:39Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `user edit test-id --name Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+
+				expCmd = `user get test-id --format json`
+				stdout = `{"id":"test-id","email":"test@test.io","name":"Test00","type":"GUEST","state":"ACTIVE","created_at":"2022-03-14T17:13:39Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUser: &model.User{
+				ID:        "test-id",
+				Email:     "test@test.io",
+				Name:      "Test00",
+				Type:      model.UserTypeGuest,
+				CreatedAt: time.Date(2022, 3, 14, 17, 13, 39, 0, time.UTC),
+			},
+		},
+
+		"Updating a user email, should fail because is not supported.": {
+			user: model.User{ID: "test-id", Email: "test2@test.io", Name: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"test-id","email":"test@test.io","name":"Test00","type":"MEMBER","state":"ACTIVE"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
-			expUser: &model.User{ID: "test-id", Email: "test@test.io", Name: "Test00"},
+			expErr:             true,
+			expErrNotSupported: true,
+		},
+
+		"Having an error while getting the current user, should fail.": {
+			user: model.User{ID: "test-id", Email: "test@test.io", Name: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
 		},
 
 		"Having an error while calling the op CLI, should fail.": {
 			user: model.User{ID: "test-id", Email: "test@test.io", Name: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `user edit test-id --name Test00`
+				expCmd := `user get test-id --format json`
+				stdout := `{"id":"test-id","email":"test@test.io","name":"Test","type":"MEMBER","state":"ACTIVE"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `user edit test-id --name Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
@@ -221,6 +343,9 @@ func TestRepositoryEnsureUser(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expErrNotSupported {
+					assert.ErrorIs(err, storage.ErrNotSupported)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expUser, gotUser)
 			}
@@ -277,3 +402,158 @@ func TestRepositoryDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositorySuspendUser(t *testing.T) {
+	tests := map[string]struct {
+		id     string
+		mock   func(m *onepasswordclimock.OpCli)
+		expErr bool
+	}{
+		"Suspending a user correctly, should suspend the user.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user suspend test-id`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user suspend test-id`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			err = repo.SuspendUser(context.TODO(), test.id)
+
+			if test.expErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryReactivateUser(t *testing.T) {
+	tests := map[string]struct {
+		id     string
+		mock   func(m *onepasswordclimock.OpCli)
+		expErr bool
+	}{
+		"Reactivating a user correctly, should reactivate the user.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user reactivate test-id`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user reactivate test-id`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			err = repo.ReactivateUser(context.TODO(), test.id)
+
+			if test.expErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryListUsers(t *testing.T) {
+	tests := map[string]struct {
+		mock     func(m *onepasswordclimock.OpCli)
+		expUsers []model.User
+		expErr   bool
+	}{
+		"Listing the users correctly, should return the users data.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --format json`
+				stdout := `[{"id":"test-user-00","name":"Test00","email":"test0@slok.dev","type":"MEMBER","state":"ACTIVE"},{"id":"test-user-01","name":"Test01","email":"test1@slok.dev","type":"GUEST","state":"SUSPENDED"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expUsers: []model.User{
+				{ID: "test-user-00", Name: "Test00", Email: "test0@slok.dev"},
+				{ID: "test-user-01", Name: "Test01", Email: "test1@slok.dev", Type: model.UserTypeGuest, State: model.UserStateSuspended},
+			},
+		},
+
+		"Listing without users, should return an empty list.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+			},
+			expUsers: []model.User{},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `user list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotUsers, err := repo.ListUsers(context.TODO())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expUsers, gotUsers)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
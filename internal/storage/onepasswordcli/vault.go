@@ -17,11 +17,12 @@ func (r Repository) CreateVault(ctx context.Context, vault model.Vault) (*model.
 	}
 
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.VaultArg().CreateArg().RawStrArg(vault.Name).DescriptionFlag(vault.Description).FormatJSONFlag()
+	cmdArgs.VaultArg().CreateArg().RawStrArg(vault.Name).DescriptionFlag(vault.Description).
+		IconFlag(vault.Icon).AllowAdminsToManageFlag(vault.AllowAdminsToManage).FormatJSONFlag()
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ov := opVault{}
@@ -41,7 +42,7 @@ func (r Repository) GetVaultByID(ctx context.Context, id string) (*model.Vault,
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ov := opVault{}
@@ -61,7 +62,7 @@ func (r Repository) GetVaultByName(ctx context.Context, name string) (*model.Vau
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	ov := opVault{}
@@ -76,12 +77,20 @@ func (r Repository) GetVaultByName(ctx context.Context, name string) (*model.Vau
 }
 
 func (r Repository) EnsureVault(ctx context.Context, vault model.Vault) (*model.Vault, error) {
+	// Same as on creation, make sure the name (in case it has been renamed) is not used by another vault.
+	current, err := r.GetVaultByName(ctx, vault.Name)
+	if err == nil && current.ID != vault.ID {
+		return nil, fmt.Errorf("vault with name %q already exists", vault.Name)
+	}
+
+	// op can only set if admins can manage the vault on creation, `vault edit` doesn't have the flag.
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.VaultArg().EditArg().RawStrArg(vault.ID).DescriptionFlag(vault.Description)
+	cmdArgs.VaultArg().EditArg().RawStrArg(vault.ID).NameFlag(vault.Name).DescriptionFlag(vault.Description).
+		IconFlag(vault.Icon)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
 	return &vault, nil
@@ -93,12 +102,57 @@ func (r Repository) DeleteVault(ctx context.Context, id string) error {
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
+func (r Repository) CountVaultItems(ctx context.Context, id string) (int, error) {
+	// The vault data has the number of items, we don't need to list them.
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.VaultArg().GetArg().RawStrArg(id).FormatJSONFlag()
+
+	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return 0, newOpCmdError(err, stderr)
+	}
+
+	ov := struct {
+		Items int `json:"items"`
+	}{}
+	err = json.Unmarshal([]byte(stdout), &ov)
+	if err != nil {
+		return 0, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	return ov.Items, nil
+}
+
+func (r Repository) ListVaults(ctx context.Context) ([]model.Vault, error) {
+	cmdArgs := &onePasswordCliCmd{}
+	cmdArgs.VaultArg().ListArg().FormatJSONFlag()
+
+	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+	if err != nil {
+		return nil, newOpCmdError(err, stderr)
+	}
+
+	ovs := []opVault{}
+	err = json.Unmarshal([]byte(stdout), &ovs)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
+	}
+
+	vaults := make([]model.Vault, 0, len(ovs))
+	for _, ov := range ovs {
+		vaults = append(vaults, mapOpToModeVault(ov))
+	}
+
+	return vaults, nil
+}
+
+// opVault is the op vault data, op doesn't return the vault icon nor if the admins can manage the vault.
 type opVault struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
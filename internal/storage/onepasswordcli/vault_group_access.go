@@ -3,23 +3,56 @@ package onepasswordcli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
 func (r *Repository) EnsureVaultGroupAccess(ctx context.Context, groupAccess model.VaultGroupAccess) error {
-	// Delete first to revoke every access and then add the required one again.
-	// Ignore error in case there isn't an access already.
-	_ = r.DeleteVaultGroupAccess(ctx, groupAccess.VaultID, groupAccess.GroupID)
+	// Get the current access to only grant and revoke the permissions that changed, this way
+	// the group doesn't lose the access to the vault in the meantime.
+	current, err := r.GetVaultGroupAccessByID(ctx, groupAccess.VaultID, groupAccess.GroupID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get current access: %w", err)
+	}
+
+	// New access, grant everything.
+	if current == nil {
+		return r.grantVaultGroupPermissions(ctx, groupAccess.VaultID, groupAccess.GroupID, mapModelToOpPermissions(groupAccess.Permissions))
+	}
+
+	// Grant first so we don't remove any access that could be required by the new permissions.
+	grant, revoke := diffOpPermissions(current.Permissions, groupAccess.Permissions)
+	if len(grant) > 0 {
+		err := r.grantVaultGroupPermissions(ctx, groupAccess.VaultID, groupAccess.GroupID, grant)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(revoke) > 0 {
+		cmdArgs := &onePasswordCliCmd{}
+		cmdArgs.VaultArg().GroupArg().RevokeArg().VaultFlag(groupAccess.VaultID).GroupFlag(groupAccess.GroupID).NoInputFlag().PermissionsFlag(revoke)
+
+		_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+		if err != nil {
+			return newOpCmdError(err, stderr)
+		}
+	}
 
-	ps := mapModelToOpPermissions(groupAccess.Permissions)
+	return nil
+}
+
+func (r *Repository) grantVaultGroupPermissions(ctx context.Context, vaultID, groupID string, permissions []string) error {
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.VaultArg().GroupArg().GrantArg().VaultFlag(groupAccess.VaultID).GroupFlag(groupAccess.GroupID).NoInputFlag().PermissionsFlag(ps)
+	cmdArgs.VaultArg().GroupArg().GrantArg().VaultFlag(vaultID).GroupFlag(groupID).NoInputFlag().PermissionsFlag(permissions)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
@@ -31,44 +64,53 @@ func (r *Repository) DeleteVaultGroupAccess(ctx context.Context, vaultID string,
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
 func (r *Repository) GetVaultGroupAccessByID(ctx context.Context, vaultID string, groupID string) (*model.VaultGroupAccess, error) {
+	// List vault groups and get the correct group.
+	accesses, err := r.ListVaultGroupAccesses(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accesses {
+		if a.GroupID == groupID {
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("group access %q in vault %q: %w", groupID, vaultID, storage.ErrNotFound)
+}
+
+func (r *Repository) ListVaultGroupAccesses(ctx context.Context, vaultID string) ([]model.VaultGroupAccess, error) {
 	cmdArgs := &onePasswordCliCmd{}
 	cmdArgs.VaultArg().GroupArg().ListArg().RawStrArg(vaultID).FormatJSONFlag()
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
-	// List vault groups and get the correct group.
 	accesses := []opVaultGroupAccess{}
 	err = json.Unmarshal([]byte(stdout), &accesses)
 	if err != nil {
 		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
 	}
-	var access *opVaultGroupAccess
-	for _, a := range accesses {
-		if a.GroupID == groupID {
-			access = &a
-			break
-		}
-	}
 
-	if access == nil {
-		return nil, fmt.Errorf("group access %q in vault %q not found", groupID, vaultID)
+	gotAccesses := make([]model.VaultGroupAccess, 0, len(accesses))
+	for _, a := range accesses {
+		gotAccesses = append(gotAccesses, model.VaultGroupAccess{
+			VaultID:     vaultID,
+			GroupID:     a.GroupID,
+			Permissions: mapOpToModelPermissions(a.Permissions),
+		})
 	}
 
-	return &model.VaultGroupAccess{
-		VaultID:     vaultID,
-		GroupID:     groupID,
-		Permissions: mapOpToModelPermissions(access.Permissions),
-	}, nil
+	return gotAccesses, nil
 }
 
 const (
@@ -141,6 +183,27 @@ func mapModelToOpPermissions(p model.AccessPermissions) []string {
 	return ps
 }
 
+// diffOpPermissions returns the op permissions that need to be granted and revoked to go from
+// the current permissions to the desired ones.
+func diffOpPermissions(current, desired model.AccessPermissions) (grant, revoke []string) {
+	currentPs := mapModelToOpPermissions(current)
+	desiredPs := mapModelToOpPermissions(desired)
+
+	for _, p := range desiredPs {
+		if !slices.Contains(currentPs, p) {
+			grant = append(grant, p)
+		}
+	}
+
+	for _, p := range currentPs {
+		if !slices.Contains(desiredPs, p) {
+			revoke = append(revoke, p)
+		}
+	}
+
+	return grant, revoke
+}
+
 func mapOpToModelPermissions(permissions []string) model.AccessPermissions {
 	ap := model.AccessPermissions{}
 	for _, p := range permissions {
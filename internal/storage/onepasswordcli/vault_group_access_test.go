@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -21,7 +22,7 @@ func TestRepositoryEnsureVaultGroupAccess(t *testing.T) {
 		mock   func(m *onepasswordclimock.OpCli)
 		expErr bool
 	}{
-		"Creating a group access correctly, should return the data with the ID.": {
+		"Creating a group access correctly, should grant all the permissions.": {
 			access: model.VaultGroupAccess{
 				VaultID: "vault-00",
 				GroupID: "group-00",
@@ -33,27 +34,122 @@ func TestRepositoryEnsureVaultGroupAccess(t *testing.T) {
 				},
 			},
 			mock: func(m *onepasswordclimock.OpCli) {
-				// First revokes everything.
-				expCmd := `vault group revoke --vault vault-00 --group group-00`
-				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+				expCmd := `vault group list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
 
-				// Grant again.
 				expCmd = `vault group grant --vault vault-00 --group group-00 --no-input --permissions allow_viewing,allow_editing,export_items,copy_and_share_items`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
 			},
 		},
 
+		"Updating a group access, should only grant the added permissions and revoke the removed ones.": {
+			access: model.VaultGroupAccess{
+				VaultID: "vault-00",
+				GroupID: "group-00",
+				Permissions: model.AccessPermissions{
+					ViewItems:   true,
+					EditItems:   true,
+					DeleteItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-00","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault group grant --vault vault-00 --group group-00 --no-input --permissions delete_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+
+				expCmd = `vault group revoke --vault vault-00 --group group-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a group access only adding permissions, should only grant the added permissions.": {
+			access: model.VaultGroupAccess{
+				VaultID: "vault-00",
+				GroupID: "group-00",
+				Permissions: model.AccessPermissions{
+					ViewItems:   true,
+					CreateItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-00","permissions":["view_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault group grant --vault vault-00 --group group-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a group access only removing permissions, should only revoke the removed permissions.": {
+			access: model.VaultGroupAccess{
+				VaultID: "vault-00",
+				GroupID: "group-00",
+				Permissions: model.AccessPermissions{
+					ViewItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-00","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault group revoke --vault vault-00 --group group-00 --no-input --permissions create_items,edit_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a group access without changes, should not grant or revoke anything.": {
+			access: model.VaultGroupAccess{
+				VaultID: "vault-00",
+				GroupID: "group-00",
+				Permissions: model.AccessPermissions{
+					ViewItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-00","permissions":["view_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+		},
+
+		"Having an error while getting the current access, should fail.": {
+			access: model.VaultGroupAccess{VaultID: "vault-00", GroupID: "group-00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+
 		"Having an error while calling the create op CLI action, should fail.": {
 			access: model.VaultGroupAccess{VaultID: "vault-00", GroupID: "group-00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `vault group revoke --vault vault-00 --group group-00`
-				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+				expCmd := `vault group list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
 
 				expCmd = `vault group grant --vault vault-00 --group group-00 --no-input`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
 		},
+
+		"Having an error while revoking the removed permissions, should fail.": {
+			access: model.VaultGroupAccess{VaultID: "vault-00", GroupID: "group-00", Permissions: model.AccessPermissions{ViewItems: true}},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-00","permissions":["view_items","create_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault group revoke --vault vault-00 --group group-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -102,11 +198,12 @@ func TestRepositoryGetVaultGroupAccessByID(t *testing.T) {
 ]
 `
 	tests := map[string]struct {
-		vaultID   string
-		groupID   string
-		mock      func(m *onepasswordclimock.OpCli)
-		expAccess *model.VaultGroupAccess
-		expErr    bool
+		vaultID     string
+		groupID     string
+		mock        func(m *onepasswordclimock.OpCli)
+		expAccess   *model.VaultGroupAccess
+		expErr      bool
+		expNotFound bool
 	}{
 		"Getting an access correctly, should return the acess data.": {
 			vaultID: "vault-00",
@@ -133,7 +230,8 @@ func TestRepositoryGetVaultGroupAccessByID(t *testing.T) {
 				expCmd := `vault group list vault-00 --format json`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
-			expErr: true,
+			expErr:      true,
+			expNotFound: true,
 		},
 
 		"Having an error while calling the op CLI, should fail.": {
@@ -162,6 +260,9 @@ func TestRepositoryGetVaultGroupAccessByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expAccess, gotAccess)
 			}
@@ -171,6 +272,72 @@ func TestRepositoryGetVaultGroupAccessByID(t *testing.T) {
 	}
 }
 
+func TestRepositoryListVaultGroupAccesses(t *testing.T) {
+	tests := map[string]struct {
+		vaultID     string
+		mock        func(m *onepasswordclimock.OpCli)
+		expAccesses []model.VaultGroupAccess
+		expErr      bool
+	}{
+		"Listing the accesses correctly, should return the accesses data.": {
+			vaultID: "vault-00",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				stdout := `[{"id":"group-id","permissions":["manage_vault"]},{"id":"group-id-2","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expAccesses: []model.VaultGroupAccess{
+				{
+					VaultID:     "vault-00",
+					GroupID:     "group-id",
+					Permissions: model.AccessPermissions{ManageVault: true},
+				},
+				{
+					VaultID: "vault-00",
+					GroupID: "group-id-2",
+					Permissions: model.AccessPermissions{
+						ViewItems:   true,
+						CreateItems: true,
+						EditItems:   true,
+					},
+				},
+			},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			vaultID: "vault-00",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault group list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotAccesses, err := repo.ListVaultGroupAccesses(context.TODO(), test.vaultID)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expAccesses, gotAccesses)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRepositoryDeleteVaultGroupAccess(t *testing.T) {
 	tests := map[string]struct {
 		access model.VaultGroupAccess
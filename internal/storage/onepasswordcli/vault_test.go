@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -23,15 +24,16 @@ func TestRepositoryCreateVault(t *testing.T) {
 		expErr   bool
 	}{
 		"Creating a vault correctly, should return the data with the ID.": {
-			vault: model.Vault{Name: "test-00", Description: "Test00"},
+			vault: model.Vault{Name: "test-00", Description: "Test00", Icon: "airplane", AllowAdminsToManage: true},
 			mock: func(m *onepasswordclimock.OpCli) {
 				expCmd := `vault get test-00 --format json`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("vault doesn't exist"))
 
-				expCmd = `vault create test-00 --description Test00 --format json`
-				stdout := `{"id":"1234567890","name":"test-00","description":"Test00"}`
+				expCmd = `vault create test-00 --description Test00 --icon airplane --allow-admins-to-manage true --format json`
+				stdout := `{"id":"1234567890","name":"test-00","description":"Test00","attribute_version":1,"content_version":1,"items":0,"type":"USER_CREATED","created_at":"2024-10-22T10:15:04Z","updated_at":"2024-10-22T10:15:04Z"}`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
+			// op doesn't return the icon nor if the admins can manage the vault.
 			expVault: &model.Vault{
 				ID:          "1234567890",
 				Name:        "test-00",
@@ -55,7 +57,7 @@ func TestRepositoryCreateVault(t *testing.T) {
 				expCmd := `vault get test-00 --format json`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("vault doesn't exist"))
 
-				expCmd = `vault create test-00 --description Test00 --format json`
+				expCmd = `vault create test-00 --description Test00 --allow-admins-to-manage false --format json`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
@@ -86,18 +88,24 @@ func TestRepositoryCreateVault(t *testing.T) {
 	}
 }
 
+func TestOpCliVaultFlags(t *testing.T) {
+	assertOpCliFlags(t, "vault create", "--description", "--icon", "--allow-admins-to-manage", "--format")
+	assertOpCliFlags(t, "vault edit", "--name", "--description", "--icon")
+}
+
 func TestRepositoryGetVaultByID(t *testing.T) {
 	tests := map[string]struct {
-		id       string
-		mock     func(m *onepasswordclimock.OpCli)
-		expVault *model.Vault
-		expErr   bool
+		id          string
+		mock        func(m *onepasswordclimock.OpCli)
+		expVault    *model.Vault
+		expErr      bool
+		expNotFound bool
 	}{
 		"Getting a vault correctly, should return the vault data.": {
 			id: "test-id",
 			mock: func(m *onepasswordclimock.OpCli) {
 				expCmd := `vault get test-id --format json`
-				stdout := `{"id":"1234567890","name":"test-00","description":"Test00"}`
+				stdout := `{"id":"1234567890","name":"test-00","description":"Test00","attribute_version":2,"content_version":12,"items":5,"type":"USER_CREATED","created_at":"2024-10-22T10:15:04Z","updated_at":"2024-10-23T08:01:55Z"}`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
 			expVault: &model.Vault{
@@ -107,6 +115,17 @@ func TestRepositoryGetVaultByID(t *testing.T) {
 			},
 		},
 
+		"Getting a missing vault, should fail with a not found error.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-id --format json`
+				stderr := `[ERROR] 2022/03/14 17:13:39 "test-id" isn't a vault in this account.`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", stderr, fmt.Errorf("exit status 1"))
+			},
+			expErr:      true,
+			expNotFound: true,
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			id: "test-id",
 			mock: func(m *onepasswordclimock.OpCli) {
@@ -132,6 +151,9 @@ func TestRepositoryGetVaultByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expVault, gotVault)
 			}
@@ -151,16 +173,59 @@ func TestRepositoryEnsureVault(t *testing.T) {
 		"Updating a vault correctly, should update the user data.": {
 			vault: model.Vault{ID: "test-id", Name: "test-00", Description: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `vault edit test-id --description Test00`
+				expCmd := `vault get test-00 --format json`
+				stdout := `{"id":"test-id","name":"test-00","description":"Test"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault edit test-id --name test-00 --description Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
 			},
 			expVault: &model.Vault{ID: "test-id", Name: "test-00", Description: "Test00"},
 		},
 
+		"Renaming a vault, should update the vault name.": {
+			vault: model.Vault{ID: "test-id", Name: "test-01", Description: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-01 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("vault doesn't exist"))
+
+				expCmd = `vault edit test-id --name test-01 --description Test00`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+			expVault: &model.Vault{ID: "test-id", Name: "test-01", Description: "Test00"},
+		},
+
+		"Updating a vault icon and admins management, should update the vault with them.": {
+			vault: model.Vault{ID: "test-id", Name: "test-00", Description: "Test00", Icon: "wrench", AllowAdminsToManage: true},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-00 --format json`
+				stdout := `{"id":"test-id","name":"test-00","description":"Test00","attribute_version":1,"content_version":3,"items":1,"type":"USER_CREATED","created_at":"2024-10-22T10:15:04Z","updated_at":"2024-10-22T10:15:04Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault edit test-id --name test-00 --description Test00 --icon wrench`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+			expVault: &model.Vault{ID: "test-id", Name: "test-00", Description: "Test00", Icon: "wrench", AllowAdminsToManage: true},
+		},
+
+		"Renaming a vault with the name of another vault, should fail.": {
+			vault: model.Vault{ID: "test-id", Name: "test-01", Description: "Test00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-01 --format json`
+				stdout := `{"id":"other-id","name":"test-01","description":"Test01"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expErr: true,
+		},
+
 		"Having an error while calling the op CLI, should fail.": {
 			vault: model.Vault{ID: "test-id", Name: "test-00", Description: "Test00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `vault edit test-id --description Test00`
+				expCmd := `vault get test-00 --format json`
+				stdout := `{"id":"test-id","name":"test-00","description":"Test"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault edit test-id --name test-00 --description Test00`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
@@ -238,3 +303,123 @@ func TestRepositoryDeleteVault(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryCountVaultItems(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		mock     func(m *onepasswordclimock.OpCli)
+		expItems int
+		expErr   bool
+	}{
+		"Counting the items of a vault, should return the number of items.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-id --format json`
+				stdout := `{"id":"test-id","name":"test-00","attribute_version":1,"content_version":4,"items":2,"type":"USER_CREATED","created_at":"2024-10-22T10:15:04Z","updated_at":"2024-10-22T10:15:04Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expItems: 2,
+		},
+
+		"Counting the items of an empty vault, should return zero.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-id --format json`
+				stdout := `{"id":"test-id","name":"test-00","attribute_version":1,"content_version":1,"items":0,"type":"USER_CREATED","created_at":"2024-10-22T10:15:04Z","updated_at":"2024-10-22T10:15:04Z"}`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expItems: 0,
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			id: "test-id",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault get test-id --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotItems, err := repo.CountVaultItems(context.TODO(), test.id)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expItems, gotItems)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryListVaults(t *testing.T) {
+	tests := map[string]struct {
+		mock      func(m *onepasswordclimock.OpCli)
+		expVaults []model.Vault
+		expErr    bool
+	}{
+		"Listing the vaults correctly, should return the vaults data.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault list --format json`
+				stdout := `[{"id":"test-vault-00","name":"vault-00"},{"id":"test-vault-01","name":"vault-01"}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expVaults: []model.Vault{
+				{ID: "test-vault-00", Name: "vault-00"},
+				{ID: "test-vault-01", Name: "vault-01"},
+			},
+		},
+
+		"Listing without vaults, should return an empty list.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
+			},
+			expVaults: []model.Vault{},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault list --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotVaults, err := repo.ListVaults(context.TODO())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expVaults, gotVaults)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
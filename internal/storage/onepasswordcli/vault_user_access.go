@@ -3,23 +3,55 @@ package onepasswordcli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 )
 
 func (r *Repository) EnsureVaultUserAccess(ctx context.Context, userAccess model.VaultUserAccess) error {
-	// Delete first to revoke every access and then add the required one again.
-	// Ignore error in case there isn't an access already.
-	_ = r.DeleteVaultUserAccess(ctx, userAccess.VaultID, userAccess.UserID)
+	// Get the current access to only grant and revoke the permissions that changed, this way
+	// the user doesn't lose the access to the vault in the meantime.
+	current, err := r.GetVaultUserAccessByID(ctx, userAccess.VaultID, userAccess.UserID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get current access: %w", err)
+	}
+
+	// New access, grant everything.
+	if current == nil {
+		return r.grantVaultUserPermissions(ctx, userAccess.VaultID, userAccess.UserID, mapModelToOpPermissions(userAccess.Permissions))
+	}
+
+	// Grant first so we don't remove any access that could be required by the new permissions.
+	grant, revoke := diffOpPermissions(current.Permissions, userAccess.Permissions)
+	if len(grant) > 0 {
+		err := r.grantVaultUserPermissions(ctx, userAccess.VaultID, userAccess.UserID, grant)
+		if err != nil {
+			return err
+		}
+	}
 
-	ps := mapModelToOpPermissions(userAccess.Permissions)
+	if len(revoke) > 0 {
+		cmdArgs := &onePasswordCliCmd{}
+		cmdArgs.VaultArg().UserArg().RevokeArg().VaultFlag(userAccess.VaultID).UserFlag(userAccess.UserID).NoInputFlag().PermissionsFlag(revoke)
+
+		_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
+		if err != nil {
+			return newOpCmdError(err, stderr)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) grantVaultUserPermissions(ctx context.Context, vaultID, userID string, permissions []string) error {
 	cmdArgs := &onePasswordCliCmd{}
-	cmdArgs.VaultArg().UserArg().GrantArg().VaultFlag(userAccess.VaultID).UserFlag(userAccess.UserID).NoInputFlag().PermissionsFlag(ps)
+	cmdArgs.VaultArg().UserArg().GrantArg().VaultFlag(vaultID).UserFlag(userID).NoInputFlag().PermissionsFlag(permissions)
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
@@ -31,44 +63,53 @@ func (r *Repository) DeleteVaultUserAccess(ctx context.Context, vaultID string,
 
 	_, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return newOpCmdError(err, stderr)
 	}
 
 	return nil
 }
 
 func (r *Repository) GetVaultUserAccessByID(ctx context.Context, vaultID string, userID string) (*model.VaultUserAccess, error) {
+	// List vault users and get the correct user.
+	accesses, err := r.ListVaultUserAccesses(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accesses {
+		if a.UserID == userID {
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user access %q in vault %q: %w", userID, vaultID, storage.ErrNotFound)
+}
+
+func (r *Repository) ListVaultUserAccesses(ctx context.Context, vaultID string) ([]model.VaultUserAccess, error) {
 	cmdArgs := &onePasswordCliCmd{}
 	cmdArgs.VaultArg().UserArg().ListArg().RawStrArg(vaultID).FormatJSONFlag()
 
 	stdout, stderr, err := r.cli.RunOpCmd(ctx, cmdArgs.GetArgs())
 	if err != nil {
-		return nil, fmt.Errorf("op cli command failed: %w: %s", err, stderr)
+		return nil, newOpCmdError(err, stderr)
 	}
 
-	// List vault users and get the correct user.
 	accesses := []opVaultUserAccess{}
 	err = json.Unmarshal([]byte(stdout), &accesses)
 	if err != nil {
 		return nil, fmt.Errorf("could not unmarshal op cli stdout: %w", err)
 	}
-	var access *opVaultUserAccess
-	for _, a := range accesses {
-		if a.UserID == userID {
-			access = &a
-			break
-		}
-	}
 
-	if access == nil {
-		return nil, fmt.Errorf("user access %q in vault %q not found", userID, vaultID)
+	gotAccesses := make([]model.VaultUserAccess, 0, len(accesses))
+	for _, a := range accesses {
+		gotAccesses = append(gotAccesses, model.VaultUserAccess{
+			VaultID:     vaultID,
+			UserID:      a.UserID,
+			Permissions: mapOpToModelPermissions(a.Permissions),
+		})
 	}
 
-	return &model.VaultUserAccess{
-		VaultID:     vaultID,
-		UserID:      userID,
-		Permissions: mapOpToModelPermissions(access.Permissions),
-	}, nil
+	return gotAccesses, nil
 }
 
 type opVaultUserAccess struct {
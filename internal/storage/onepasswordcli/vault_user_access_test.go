@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli/onepasswordclimock"
 )
@@ -21,7 +22,7 @@ func TestRepositoryEnsureVaultUserAccess(t *testing.T) {
 		mock   func(m *onepasswordclimock.OpCli)
 		expErr bool
 	}{
-		"Creating a user access correctly, should return the data with the ID.": {
+		"Creating a user access correctly, should grant all the permissions.": {
 			access: model.VaultUserAccess{
 				VaultID: "vault-00",
 				UserID:  "user-00",
@@ -33,27 +34,122 @@ func TestRepositoryEnsureVaultUserAccess(t *testing.T) {
 				},
 			},
 			mock: func(m *onepasswordclimock.OpCli) {
-				// First revokes everything.
-				expCmd := `vault user revoke --vault vault-00 --user user-00`
-				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+				expCmd := `vault user list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
 
-				// Grant again.
 				expCmd = `vault user grant --vault vault-00 --user user-00 --no-input --permissions allow_viewing,allow_editing,export_items,copy_and_share_items`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
 			},
 		},
 
+		"Updating a user access, should only grant the added permissions and revoke the removed ones.": {
+			access: model.VaultUserAccess{
+				VaultID: "vault-00",
+				UserID:  "user-00",
+				Permissions: model.AccessPermissions{
+					ViewItems:   true,
+					EditItems:   true,
+					DeleteItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-00","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault user grant --vault vault-00 --user user-00 --no-input --permissions delete_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+
+				expCmd = `vault user revoke --vault vault-00 --user user-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a user access only adding permissions, should only grant the added permissions.": {
+			access: model.VaultUserAccess{
+				VaultID: "vault-00",
+				UserID:  "user-00",
+				Permissions: model.AccessPermissions{
+					ViewItems:   true,
+					CreateItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-00","permissions":["view_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault user grant --vault vault-00 --user user-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a user access only removing permissions, should only revoke the removed permissions.": {
+			access: model.VaultUserAccess{
+				VaultID: "vault-00",
+				UserID:  "user-00",
+				Permissions: model.AccessPermissions{
+					ViewItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-00","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault user revoke --vault vault-00 --user user-00 --no-input --permissions create_items,edit_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+			},
+		},
+
+		"Updating a user access without changes, should not grant or revoke anything.": {
+			access: model.VaultUserAccess{
+				VaultID: "vault-00",
+				UserID:  "user-00",
+				Permissions: model.AccessPermissions{
+					ViewItems: true,
+				},
+			},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-00","permissions":["view_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+		},
+
+		"Having an error while getting the current access, should fail.": {
+			access: model.VaultUserAccess{VaultID: "vault-00", UserID: "user-00"},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+
 		"Having an error while calling the create op CLI action, should fail.": {
 			access: model.VaultUserAccess{VaultID: "vault-00", UserID: "user-00"},
 			mock: func(m *onepasswordclimock.OpCli) {
-				expCmd := `vault user revoke --vault vault-00 --user user-00`
-				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", nil)
+				expCmd := `vault user list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(`[]`, "", nil)
 
 				expCmd = `vault user grant --vault vault-00 --user user-00 --no-input`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
 			},
 			expErr: true,
 		},
+
+		"Having an error while revoking the removed permissions, should fail.": {
+			access: model.VaultUserAccess{VaultID: "vault-00", UserID: "user-00", Permissions: model.AccessPermissions{ViewItems: true}},
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-00","permissions":["view_items","create_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+
+				expCmd = `vault user revoke --vault vault-00 --user user-00 --no-input --permissions create_items`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -102,11 +198,12 @@ func TestRepositoryGetVaultUserAccessByID(t *testing.T) {
 ]
 `
 	tests := map[string]struct {
-		vaultID   string
-		userID    string
-		mock      func(m *onepasswordclimock.OpCli)
-		expAccess *model.VaultUserAccess
-		expErr    bool
+		vaultID     string
+		userID      string
+		mock        func(m *onepasswordclimock.OpCli)
+		expAccess   *model.VaultUserAccess
+		expErr      bool
+		expNotFound bool
 	}{
 		"Getting an access correctly, should return the acess data.": {
 			vaultID: "vault-00",
@@ -133,7 +230,8 @@ func TestRepositoryGetVaultUserAccessByID(t *testing.T) {
 				expCmd := `vault user list vault-00 --format json`
 				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
 			},
-			expErr: true,
+			expErr:      true,
+			expNotFound: true,
 		},
 
 		"Having an error while calling the op CLI, should fail.": {
@@ -162,6 +260,9 @@ func TestRepositoryGetVaultUserAccessByID(t *testing.T) {
 
 			if test.expErr {
 				assert.Error(err)
+				if test.expNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
 			} else if assert.NoError(err) {
 				assert.Equal(test.expAccess, gotAccess)
 			}
@@ -171,6 +272,72 @@ func TestRepositoryGetVaultUserAccessByID(t *testing.T) {
 	}
 }
 
+func TestRepositoryListVaultUserAccesses(t *testing.T) {
+	tests := map[string]struct {
+		vaultID     string
+		mock        func(m *onepasswordclimock.OpCli)
+		expAccesses []model.VaultUserAccess
+		expErr      bool
+	}{
+		"Listing the accesses correctly, should return the accesses data.": {
+			vaultID: "vault-00",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				stdout := `[{"id":"user-id","permissions":["manage_vault"]},{"id":"user-id-2","permissions":["view_items","create_items","edit_items"]}]`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return(stdout, "", nil)
+			},
+			expAccesses: []model.VaultUserAccess{
+				{
+					VaultID:     "vault-00",
+					UserID:      "user-id",
+					Permissions: model.AccessPermissions{ManageVault: true},
+				},
+				{
+					VaultID: "vault-00",
+					UserID:  "user-id-2",
+					Permissions: model.AccessPermissions{
+						ViewItems:   true,
+						CreateItems: true,
+						EditItems:   true,
+					},
+				},
+			},
+		},
+
+		"Having an error while calling the op CLI, should fail.": {
+			vaultID: "vault-00",
+			mock: func(m *onepasswordclimock.OpCli) {
+				expCmd := `vault user list vault-00 --format json`
+				m.On("RunOpCmd", mock.Anything, strings.Fields(expCmd)).Once().Return("", "", fmt.Errorf("something"))
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			mc := &onepasswordclimock.OpCli{}
+			test.mock(mc)
+
+			repo, err := onepasswordcli.NewRepository(mc)
+			require.NoError(err)
+
+			gotAccesses, err := repo.ListVaultUserAccesses(context.TODO(), test.vaultID)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expAccesses, gotAccesses)
+			}
+
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRepositoryDeleteVaultUserAccess(t *testing.T) {
 	tests := map[string]struct {
 		access model.VaultUserAccess
@@ -0,0 +1,138 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+// CreateGroup creates the group, SCIM groups don't have description so it's ignored.
+func (r Repository) CreateGroup(ctx context.Context, group model.Group) (*model.Group, error) {
+	// Like 1password, SCIM allows multiple groups with the same name, we add this to make sure
+	// this doesn't happen.
+	_, err := r.GetGroupByName(ctx, group.Name)
+	if err == nil {
+		return nil, fmt.Errorf("group with name %q already exists", group.Name)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not check the group name is not used: %w", err)
+	}
+
+	sg := scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		DisplayName: group.Name,
+	}
+
+	gotSG := scimGroup{}
+	_, err = r.do(ctx, http.MethodPost, "/Groups", nil, sg, &gotSG)
+	if err != nil {
+		return nil, err
+	}
+
+	gotGroup := mapSCIMToModelGroup(gotSG)
+
+	return &gotGroup, nil
+}
+
+func (r Repository) GetGroupByID(ctx context.Context, id string) (*model.Group, error) {
+	sg, err := r.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	gotGroup := mapSCIMToModelGroup(*sg)
+
+	return &gotGroup, nil
+}
+
+func (r Repository) GetGroupByName(ctx context.Context, name string) (*model.Group, error) {
+	query := url.Values{
+		"filter":             {filterEq("displayName", name)},
+		"excludedAttributes": {"members"},
+	}
+	sgs, err := list[scimGroup](ctx, r, "/Groups", query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sgs) == 0 {
+		return nil, fmt.Errorf("group %q: %w", name, storage.ErrNotFound)
+	}
+
+	gotGroup := mapSCIMToModelGroup(sgs[0])
+
+	return &gotGroup, nil
+}
+
+// EnsureGroup updates the group name, SCIM groups don't have description so it's ignored.
+func (r Repository) EnsureGroup(ctx context.Context, group model.Group) (*model.Group, error) {
+	// Same as on creation, make sure the name (in case it has been renamed) is not used by another group.
+	current, err := r.GetGroupByName(ctx, group.Name)
+	if err == nil && current.ID != group.ID {
+		return nil, fmt.Errorf("group with name %q already exists", group.Name)
+	}
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not check the group name is not used: %w", err)
+	}
+
+	patch := newSCIMPatch(scimPatchOp{Op: "replace", Path: "displayName", Value: group.Name})
+	_, err = r.do(ctx, http.MethodPatch, "/Groups/"+url.PathEscape(group.ID), nil, patch, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Description = ""
+	return &group, nil
+}
+
+func (r Repository) DeleteGroup(ctx context.Context, id string) error {
+	_, err := r.do(ctx, http.MethodDelete, "/Groups/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
+
+func (r Repository) ListGroups(ctx context.Context) ([]model.Group, error) {
+	sgs, err := list[scimGroup](ctx, r, "/Groups", url.Values{"excludedAttributes": {"members"}})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]model.Group, 0, len(sgs))
+	for _, sg := range sgs {
+		groups = append(groups, mapSCIMToModelGroup(sg))
+	}
+
+	return groups, nil
+}
+
+func (r Repository) getGroup(ctx context.Context, id string) (*scimGroup, error) {
+	sg := scimGroup{}
+	_, err := r.do(ctx, http.MethodGet, "/Groups/"+url.PathEscape(id), nil, nil, &sg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sg, nil
+}
+
+type scimGroup struct {
+	Schemas     []string          `json:"schemas,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	DisplayName string            `json:"displayName"`
+	Members     []scimGroupMember `json:"members,omitempty"`
+}
+
+type scimGroupMember struct {
+	Value string `json:"value"`
+}
+
+func mapSCIMToModelGroup(g scimGroup) model.Group {
+	return model.Group{
+		ID:   g.ID,
+		Name: g.DisplayName,
+	}
+}
@@ -0,0 +1,252 @@
+package scim_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/scim"
+)
+
+func TestRepositoryGroup(t *testing.T) {
+	tests := map[string]struct {
+		calls          []testCall
+		exec           func(repo *scim.Repository) (*model.Group, error)
+		expGroup       *model.Group
+		expErr         bool
+		expErrNotFound bool
+	}{
+		"Creating a group correctly, should return the data with the ID.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":0,"startIndex":1,"itemsPerPage":2,"Resources":[]}`,
+				},
+				{
+					method:     http.MethodPost,
+					path:       "/Groups",
+					body:       `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"test-00"}`,
+					respStatus: http.StatusCreated,
+					respBody:   `{"id":"1234567890","displayName":"test-00","members":[]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.CreateGroup(context.TODO(), model.Group{Name: "test-00"})
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-00"},
+		},
+
+		"Creating a group with description, should ignore the description.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":0,"startIndex":1,"itemsPerPage":2,"Resources":[]}`,
+				},
+				{
+					method:     http.MethodPost,
+					path:       "/Groups",
+					body:       `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"test-00"}`,
+					respStatus: http.StatusCreated,
+					respBody:   `{"id":"1234567890","displayName":"test-00","members":[]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.CreateGroup(context.TODO(), model.Group{Name: "test-00", Description: "Test00"})
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-00"},
+		},
+
+		"Creating a group with a name used by another group, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":1,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"0987654321","displayName":"test-00"}]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.CreateGroup(context.TODO(), model.Group{Name: "test-00"})
+			},
+			expErr: true,
+		},
+
+		"Having an error while checking the group name on creation, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusInternalServerError,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.CreateGroup(context.TODO(), model.Group{Name: "test-00"})
+			},
+			expErr: true,
+		},
+
+		"Getting a group by ID, should return the group.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/1234567890", respStatus: http.StatusOK, respBody: `{"id":"1234567890","displayName":"test-00"}`},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.GetGroupByID(context.TODO(), "1234567890")
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-00"},
+		},
+
+		"Getting a missing group by ID, should fail with not found.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/1234567890", respStatus: http.StatusNotFound},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.GetGroupByID(context.TODO(), "1234567890")
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Getting a group by name, should filter the groups by display name.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":1,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"1234567890","displayName":"test-00"}]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.GetGroupByName(context.TODO(), "test-00")
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-00"},
+		},
+
+		"Getting a missing group by name, should fail with not found.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-00"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":0,"startIndex":1,"itemsPerPage":2,"Resources":[]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.GetGroupByName(context.TODO(), "test-00")
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Ensuring a group, should patch the group name and ignore the description.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-01"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":0,"startIndex":1,"itemsPerPage":2,"Resources":[]}`,
+				},
+				{
+					method:     http.MethodPatch,
+					path:       "/Groups/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"displayName","value":"test-01"}]}`,
+					respStatus: http.StatusNoContent,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.EnsureGroup(context.TODO(), model.Group{ID: "1234567890", Name: "test-01", Description: "Test01"})
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-01"},
+		},
+
+		"Ensuring a group with its own name, should patch the group.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-01"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":1,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"1234567890","displayName":"test-01"}]}`,
+				},
+				{
+					method:     http.MethodPatch,
+					path:       "/Groups/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"displayName","value":"test-01"}]}`,
+					respStatus: http.StatusNoContent,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.EnsureGroup(context.TODO(), model.Group{ID: "1234567890", Name: "test-01"})
+			},
+			expGroup: &model.Group{ID: "1234567890", Name: "test-01"},
+		},
+
+		"Renaming a group with a name used by another group, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Groups?filter=displayName eq "test-01"&excludedAttributes=members&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":1,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"0987654321","displayName":"test-01"}]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return repo.EnsureGroup(context.TODO(), model.Group{ID: "1234567890", Name: "test-01"})
+			},
+			expErr: true,
+		},
+
+		"Deleting a group, should delete the group.": {
+			calls: []testCall{
+				{method: http.MethodDelete, path: "/Groups/1234567890", respStatus: http.StatusNoContent},
+			},
+			exec: func(repo *scim.Repository) (*model.Group, error) {
+				return nil, repo.DeleteGroup(context.TODO(), "1234567890")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			gotGroup, err := test.exec(repo)
+
+			if test.expErr {
+				require.Error(err)
+				if test.expErrNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
+			} else if assert.NoError(err) {
+				assert.Equal(test.expGroup, gotGroup)
+			}
+		})
+	}
+}
+
+func TestRepositoryListGroups(t *testing.T) {
+	calls := []testCall{
+		{
+			method:     http.MethodGet,
+			path:       "/Groups?excludedAttributes=members&startIndex=1&count=2",
+			respStatus: http.StatusOK,
+			respBody:   `{"totalResults":2,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"g0","displayName":"group-0"},{"id":"g1","displayName":"group-1"}]}`,
+		},
+	}
+	repo := newTestRepository(t, calls)
+
+	gotGroups, err := repo.ListGroups(context.TODO())
+
+	require.NoError(t, err)
+	assert.Equal(t, []model.Group{{ID: "g0", Name: "group-0"}, {ID: "g1", Name: "group-1"}}, gotGroups)
+}
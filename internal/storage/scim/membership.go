@@ -0,0 +1,94 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+func (r Repository) EnsureMembership(ctx context.Context, membership model.Membership) error {
+	// SCIM groups don't have roles.
+	if membership.Role != model.MembershipRoleMember {
+		return errNotSupported("group manager role")
+	}
+
+	patch := newSCIMPatch(scimPatchOp{
+		Op:    "add",
+		Path:  "members",
+		Value: []scimGroupMember{{Value: membership.UserID}},
+	})
+	_, err := r.do(ctx, http.MethodPatch, "/Groups/"+url.PathEscape(membership.GroupID), nil, patch, nil)
+	return err
+}
+
+func (r Repository) DeleteMembership(ctx context.Context, membership model.Membership) error {
+	patch := newSCIMPatch(scimPatchOp{
+		Op:   "remove",
+		Path: fmt.Sprintf("members[%s]", filterEq("value", membership.UserID)),
+	})
+	_, err := r.do(ctx, http.MethodPatch, "/Groups/"+url.PathEscape(membership.GroupID), nil, patch, nil)
+	return err
+}
+
+func (r Repository) GetMembershipByID(ctx context.Context, groupID, userID string) (*model.Membership, error) {
+	sg, err := r.getGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range sg.Members {
+		if m.Value == userID {
+			return &model.Membership{
+				UserID:  userID,
+				GroupID: groupID,
+				Role:    model.MembershipRoleMember,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("member %q in group %q: %w", userID, groupID, storage.ErrNotFound)
+}
+
+func (r Repository) ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error) {
+	sg, err := r.getGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]model.GroupMember, 0, len(sg.Members))
+	if len(sg.Members) == 0 {
+		return members, nil
+	}
+
+	// SCIM group members only have the user ID, list the users once (instead of getting every member)
+	// to have their data.
+	users, err := r.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list users: %w", err)
+	}
+
+	usersByID := make(map[string]model.User, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+
+	for _, m := range sg.Members {
+		// The member can be missing from the users list (e.g: eventual consistency, deprovisioned users...),
+		// this shouldn't make the rest of the members disappear, so we only have its ID.
+		user, ok := usersByID[m.Value]
+		if !ok {
+			user = model.User{ID: m.Value}
+		}
+
+		members = append(members, model.GroupMember{
+			User: user,
+			Role: model.MembershipRoleMember,
+		})
+	}
+
+	return members, nil
+}
@@ -0,0 +1,208 @@
+package scim_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+func TestRepositoryEnsureMembership(t *testing.T) {
+	tests := map[string]struct {
+		membership    model.Membership
+		calls         []testCall
+		expErr        bool
+		expErrNotSupp bool
+	}{
+		"Ensuring a member, should add the user to the group members.": {
+			membership: model.Membership{GroupID: "group-00", UserID: "user-00", Role: model.MembershipRoleMember},
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Groups/group-00",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"user-00"}]}]}`,
+					respStatus: http.StatusNoContent,
+				},
+			},
+		},
+
+		"Ensuring a manager, should fail as not supported.": {
+			membership:    model.Membership{GroupID: "group-00", UserID: "user-00", Role: model.MembershipRoleManager},
+			expErr:        true,
+			expErrNotSupp: true,
+		},
+
+		"Having an error while adding the member, should fail.": {
+			membership: model.Membership{GroupID: "group-00", UserID: "user-00", Role: model.MembershipRoleMember},
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Groups/group-00",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"user-00"}]}]}`,
+					respStatus: http.StatusBadRequest,
+					respBody:   `{"detail":"invalid user","status":"400"}`,
+				},
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			err := repo.EnsureMembership(context.TODO(), test.membership)
+
+			if test.expErr {
+				assert.Error(err)
+				if test.expErrNotSupp {
+					assert.ErrorIs(err, storage.ErrNotSupported)
+				}
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestRepositoryDeleteMembership(t *testing.T) {
+	calls := []testCall{
+		{
+			method:     http.MethodPatch,
+			path:       "/Groups/group-00",
+			body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"remove","path":"members[value eq \"user-00\"]"}]}`,
+			respStatus: http.StatusNoContent,
+		},
+	}
+	repo := newTestRepository(t, calls)
+
+	err := repo.DeleteMembership(context.TODO(), model.Membership{GroupID: "group-00", UserID: "user-00"})
+
+	assert.NoError(t, err)
+}
+
+func TestRepositoryGetMembershipByID(t *testing.T) {
+	tests := map[string]struct {
+		userID         string
+		calls          []testCall
+		expMembership  *model.Membership
+		expErr         bool
+		expErrNotFound bool
+	}{
+		"Getting a member of the group, should return the membership.": {
+			userID: "user-01",
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test","members":[{"value":"user-00"},{"value":"user-01"}]}`},
+			},
+			expMembership: &model.Membership{GroupID: "group-00", UserID: "user-01", Role: model.MembershipRoleMember},
+		},
+
+		"Getting a user that is not a member of the group, should fail with not found.": {
+			userID: "user-02",
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test","members":[{"value":"user-00"},{"value":"user-01"}]}`},
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Getting a member of a missing group, should fail with not found.": {
+			userID: "user-00",
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusNotFound},
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			gotMembership, err := repo.GetMembershipByID(context.TODO(), "group-00", test.userID)
+
+			if test.expErr {
+				require.Error(err)
+				if test.expErrNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
+			} else if assert.NoError(err) {
+				assert.Equal(test.expMembership, gotMembership)
+			}
+		})
+	}
+}
+
+func TestRepositoryListGroupMembers(t *testing.T) {
+	tests := map[string]struct {
+		calls      []testCall
+		expMembers []model.GroupMember
+		expErr     bool
+	}{
+		"Listing the group members, should list the users once to get the members data.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test","members":[{"value":"1234567890"},{"value":"u1"}]}`},
+				{method: http.MethodGet, path: "/Users?startIndex=1&count=2", respStatus: http.StatusOK, respBody: `{"totalResults":3,"startIndex":1,"itemsPerPage":2,"Resources":[` + testSCIMUser00 + `,{"id":"u1","userName":"u1@slok.dev","displayName":"u1","active":true}]}`},
+				{method: http.MethodGet, path: "/Users?startIndex=3&count=2", respStatus: http.StatusOK, respBody: `{"totalResults":3,"startIndex":3,"itemsPerPage":1,"Resources":[{"id":"u2","userName":"u2@slok.dev","displayName":"u2","active":true}]}`},
+			},
+			expMembers: []model.GroupMember{
+				{User: testModelUser00, Role: model.MembershipRoleMember},
+				{User: model.User{ID: "u1", Email: "u1@slok.dev", Name: "u1", Type: model.UserTypeMember, State: model.UserStateActive}, Role: model.MembershipRoleMember},
+			},
+		},
+
+		"Listing the group members with a member missing on the users, should return the member only with its ID.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test","members":[{"value":"1234567890"},{"value":"u1"}]}`},
+				{method: http.MethodGet, path: "/Users?startIndex=1&count=2", respStatus: http.StatusOK, respBody: `{"totalResults":1,"startIndex":1,"itemsPerPage":1,"Resources":[` + testSCIMUser00 + `]}`},
+			},
+			expMembers: []model.GroupMember{
+				{User: testModelUser00, Role: model.MembershipRoleMember},
+				{User: model.User{ID: "u1"}, Role: model.MembershipRoleMember},
+			},
+		},
+
+		"Listing the members of a group without members, should not list the users.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test"}`},
+			},
+			expMembers: []model.GroupMember{},
+		},
+
+		"Having an error while listing the users, should fail.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Groups/group-00", respStatus: http.StatusOK, respBody: `{"id":"group-00","displayName":"test","members":[{"value":"1234567890"}]}`},
+				{method: http.MethodGet, path: "/Users?startIndex=1&count=2", respStatus: http.StatusInternalServerError},
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			gotMembers, err := repo.ListGroupMembers(context.TODO(), "group-00")
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				require.Equal(test.expMembers, gotMembers)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+// RepositoryConfig is the configuration of NewRepository.
+type RepositoryConfig struct {
+	// URL is the SCIM API base URL of the 1password SCIM bridge (e.g: https://scim.example.com/scim/v2).
+	URL string
+	// Token is the SCIM bridge bearer token.
+	Token string
+	// HTTPClient is the client used to call the SCIM bridge.
+	HTTPClient *http.Client
+	// PageSize is the number of resources requested on every page when listing.
+	PageSize int
+}
+
+func (c *RepositoryConfig) defaults() error {
+	if c.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url scheme must be http or https")
+	}
+	c.URL = strings.TrimSuffix(c.URL, "/")
+
+	if c.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	if c.PageSize <= 0 {
+		c.PageSize = 100
+	}
+
+	return nil
+}
+
+// NewRepository returns a 1password SCIM bridge based repository.
+//
+// SCIM only knows about users and groups, so the vault operations and the features that
+// SCIM doesn't have (e.g: guest users, group managers) will fail with storage.ErrNotSupported.
+func NewRepository(config RepositoryConfig) (*Repository, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &Repository{cfg: config}, nil
+}
+
+// Repository knows how to execute 1password operations using the SCIM 2.0 API of the 1password SCIM bridge.
+type Repository struct {
+	cfg RepositoryConfig
+}
+
+var _ storage.Repository = &Repository{}
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimSchemaUser    = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup   = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaPatchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// errNotSupported returns the error of the operations that can't be done using SCIM.
+func errNotSupported(op string) error {
+	return fmt.Errorf("%s is unsupported by SCIM backend: %w", op, storage.ErrNotSupported)
+}
+
+// scimError is the SCIM error response body.
+type scimError struct {
+	Detail string `json:"detail"`
+}
+
+// do executes a request on the SCIM API and decodes the response into out (if not nil).
+func (r Repository) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) (int, error) {
+	u := r.cfg.URL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("could not marshal request body: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return 0, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	req.Header.Set("Accept", scimContentType)
+	if in != nil {
+		req.Header.Set("Content-Type", scimContentType)
+	}
+
+	resp, err := r.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("scim request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("could not read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, newSCIMError(method, path, resp.StatusCode, respBody)
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent && len(respBody) > 0 {
+		err = json.Unmarshal(respBody, out)
+		if err != nil {
+			return resp.StatusCode, fmt.Errorf("could not unmarshal scim response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// newSCIMError returns the error of a failed SCIM request, known errors will be mapped to
+// storage errors so they can be checked by the callers.
+func newSCIMError(method, path string, status int, body []byte) error {
+	detail := strings.TrimSpace(string(body))
+	se := scimError{}
+	if err := json.Unmarshal(body, &se); err == nil && se.Detail != "" {
+		detail = se.Detail
+	}
+
+	if status == http.StatusNotFound {
+		return fmt.Errorf("scim %s %s failed with status %d: %w: %s", method, path, status, storage.ErrNotFound, detail)
+	}
+
+	return fmt.Errorf("scim %s %s failed with status %d: %s", method, path, status, detail)
+}
+
+type scimListResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	StartIndex   int `json:"startIndex"`
+	ItemsPerPage int `json:"itemsPerPage"`
+	Resources    []T `json:"Resources"`
+}
+
+// maxListPages is the maximum number of pages got when listing, it protects from SCIM servers that never end
+// the pagination.
+const maxListPages = 10000
+
+// list gets all the resources of the path, following the pagination.
+func list[T any](ctx context.Context, r Repository, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	res := []T{}
+	// SCIM pagination is 1-based.
+	startIndex := 1
+	for pages := 0; pages < maxListPages; pages++ {
+		query.Set("startIndex", strconv.Itoa(startIndex))
+		query.Set("count", strconv.Itoa(r.cfg.PageSize))
+
+		page := scimListResponse[T]{}
+		_, err := r.do(ctx, http.MethodGet, path, query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		// The start index is optional on the response, if set, it must be the requested one, if not the
+		// server is not advancing and we would get the same page forever.
+		if page.StartIndex != 0 && page.StartIndex != startIndex {
+			return nil, fmt.Errorf("scim list %s pagination is not advancing: requested start index %d, got %d", path, startIndex, page.StartIndex)
+		}
+
+		res = append(res, page.Resources...)
+		startIndex += len(page.Resources)
+
+		if len(page.Resources) == 0 || len(res) >= page.TotalResults {
+			return res, nil
+		}
+	}
+
+	return nil, fmt.Errorf("scim list %s pagination exceeded %d pages", path, maxListPages)
+}
+
+// filterEq returns a SCIM filter that matches the resources with the attribute equal to the value.
+func filterEq(attr, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf(`%s eq "%s"`, attr, value)
+}
+
+type scimPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type scimPatch struct {
+	Schemas    []string      `json:"schemas"`
+	Operations []scimPatchOp `json:"Operations"`
+}
+
+func newSCIMPatch(ops ...scimPatchOp) scimPatch {
+	return scimPatch{
+		Schemas:    []string{scimSchemaPatchOp},
+		Operations: ops,
+	}
+}
+
+type scimMeta struct {
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+}
@@ -0,0 +1,132 @@
+package scim_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/scim"
+)
+
+const testToken = "test-token"
+
+// testCall is an expected call to the SCIM test server and its response.
+type testCall struct {
+	method string
+	// path is the expected path relative to the SCIM base URL, with the query unescaped (e.g: `/Users?filter=userName eq "a"`).
+	path string
+	// body is the expected JSON body, empty means no body.
+	body       string
+	respStatus int
+	respBody   string
+}
+
+// newTestRepository returns a SCIM repository that calls a test server that expects the calls in order.
+func newTestRepository(t *testing.T, calls []testCall) *scim.Repository {
+	mu := sync.Mutex{}
+	called := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if called >= len(calls) {
+			t.Errorf("unexpected call: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		call := calls[called]
+		called++
+
+		assert.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		assert.Equal(t, call.method, r.Method)
+
+		expPath, expRawQuery, _ := strings.Cut(call.path, "?")
+		assert.Equal(t, "/scim/v2"+expPath, r.URL.Path)
+		assert.Equal(t, parseTestQuery(expRawQuery), r.URL.Query())
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if call.body == "" {
+			assert.Empty(t, body)
+		} else {
+			assert.JSONEq(t, call.body, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/scim+json")
+		w.WriteHeader(call.respStatus)
+		_, _ = w.Write([]byte(call.respBody))
+	}))
+	t.Cleanup(func() {
+		srv.Close()
+		assert.Equal(t, len(calls), called, "missing calls")
+	})
+
+	repo, err := scim.NewRepository(scim.RepositoryConfig{
+		URL:      srv.URL + "/scim/v2",
+		Token:    testToken,
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+
+	return repo
+}
+
+func parseTestQuery(rawQuery string) url.Values {
+	q := url.Values{}
+	if rawQuery == "" {
+		return q
+	}
+
+	for _, kv := range strings.Split(rawQuery, "&") {
+		k, v, _ := strings.Cut(kv, "=")
+		q.Add(k, v)
+	}
+
+	return q
+}
+
+func TestNewRepository(t *testing.T) {
+	tests := map[string]struct {
+		config scim.RepositoryConfig
+		expErr bool
+	}{
+		"A correct configuration, should not fail.": {
+			config: scim.RepositoryConfig{URL: "https://scim.slok.dev/scim/v2", Token: testToken},
+		},
+
+		"A missing URL, should fail.": {
+			config: scim.RepositoryConfig{Token: testToken},
+			expErr: true,
+		},
+
+		"An URL without HTTP scheme, should fail.": {
+			config: scim.RepositoryConfig{URL: "scim.slok.dev", Token: testToken},
+			expErr: true,
+		},
+
+		"A missing token, should fail.": {
+			config: scim.RepositoryConfig{URL: "https://scim.slok.dev/scim/v2"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := scim.NewRepository(test.config)
+
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
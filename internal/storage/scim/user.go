@@ -0,0 +1,161 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+func (r Repository) CreateUser(ctx context.Context, user model.User) (*model.User, error) {
+	if user.Type == model.UserTypeGuest {
+		return nil, errNotSupported("guest user provisioning")
+	}
+
+	su := mapModelToSCIMUser(user)
+	su.Active = true
+
+	gotSU := scimUser{}
+	_, err := r.do(ctx, http.MethodPost, "/Users", nil, su, &gotSU)
+	if err != nil {
+		return nil, err
+	}
+
+	gotUser := mapSCIMToModelUser(gotSU)
+
+	return &gotUser, nil
+}
+
+func (r Repository) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	su := scimUser{}
+	_, err := r.do(ctx, http.MethodGet, "/Users/"+url.PathEscape(id), nil, nil, &su)
+	if err != nil {
+		return nil, err
+	}
+
+	gotUser := mapSCIMToModelUser(su)
+
+	return &gotUser, nil
+}
+
+func (r Repository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	sus, err := list[scimUser](ctx, r, "/Users", url.Values{"filter": {filterEq("userName", email)}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sus) == 0 {
+		return nil, fmt.Errorf("user %q: %w", email, storage.ErrNotFound)
+	}
+
+	gotUser := mapSCIMToModelUser(sus[0])
+
+	return &gotUser, nil
+}
+
+func (r Repository) EnsureUser(ctx context.Context, user model.User) (*model.User, error) {
+	su := mapModelToSCIMUser(user)
+	patch := newSCIMPatch(
+		scimPatchOp{Op: "replace", Path: "userName", Value: su.UserName},
+		scimPatchOp{Op: "replace", Path: "displayName", Value: su.DisplayName},
+		scimPatchOp{Op: "replace", Path: "emails", Value: su.Emails},
+	)
+
+	return r.patchUser(ctx, user.ID, patch)
+}
+
+func (r Repository) DeleteUser(ctx context.Context, id string) error {
+	_, err := r.do(ctx, http.MethodDelete, "/Users/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
+
+func (r Repository) SuspendUser(ctx context.Context, id string) error {
+	_, err := r.patchUser(ctx, id, newSCIMPatch(scimPatchOp{Op: "replace", Path: "active", Value: false}))
+	return err
+}
+
+func (r Repository) ReactivateUser(ctx context.Context, id string) error {
+	_, err := r.patchUser(ctx, id, newSCIMPatch(scimPatchOp{Op: "replace", Path: "active", Value: true}))
+	return err
+}
+
+func (r Repository) ListUsers(ctx context.Context) ([]model.User, error) {
+	sus, err := list[scimUser](ctx, r, "/Users", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]model.User, 0, len(sus))
+	for _, su := range sus {
+		users = append(users, mapSCIMToModelUser(su))
+	}
+
+	return users, nil
+}
+
+// patchUser patches the user and returns it, SCIM servers can return the patched resource or
+// nothing, in that case the user will be get again.
+func (r Repository) patchUser(ctx context.Context, id string, patch scimPatch) (*model.User, error) {
+	su := scimUser{}
+	status, err := r.do(ctx, http.MethodPatch, "/Users/"+url.PathEscape(id), nil, patch, &su)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusNoContent || su.ID == "" {
+		return r.GetUserByID(ctx, id)
+	}
+
+	gotUser := mapSCIMToModelUser(su)
+
+	return &gotUser, nil
+}
+
+type scimUser struct {
+	Schemas     []string        `json:"schemas,omitempty"`
+	ID          string          `json:"id,omitempty"`
+	UserName    string          `json:"userName"`
+	DisplayName string          `json:"displayName"`
+	Emails      []scimUserEmail `json:"emails,omitempty"`
+	Active      bool            `json:"active"`
+	Meta        *scimMeta       `json:"meta,omitempty"`
+}
+
+type scimUserEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+func mapModelToSCIMUser(u model.User) scimUser {
+	return scimUser{
+		Schemas:     []string{scimSchemaUser},
+		UserName:    u.Email,
+		DisplayName: u.Name,
+		Emails:      []scimUserEmail{{Value: u.Email, Primary: true}},
+	}
+}
+
+func mapSCIMToModelUser(u scimUser) model.User {
+	user := model.User{
+		ID:    u.ID,
+		Email: u.UserName,
+		Name:  u.DisplayName,
+		// SCIM can only provision members.
+		Type:  model.UserTypeMember,
+		State: model.UserStateSuspended,
+	}
+
+	if u.Active {
+		user.State = model.UserStateActive
+	}
+
+	if u.Meta != nil {
+		user.CreatedAt = u.Meta.Created
+		user.UpdatedAt = u.Meta.LastModified
+	}
+
+	return user
+}
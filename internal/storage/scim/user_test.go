@@ -0,0 +1,305 @@
+package scim_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/scim"
+)
+
+const testSCIMUser00 = `{"id":"1234567890","userName":"test@slok.dev","displayName":"test00","emails":[{"value":"test@slok.dev","primary":true}],"active":true,"meta":{"created":"2024-01-02T03:04:05Z","lastModified":"2024-02-03T04:05:06Z"}}`
+
+var testModelUser00 = model.User{
+	ID:        "1234567890",
+	Email:     "test@slok.dev",
+	Name:      "test00",
+	Type:      model.UserTypeMember,
+	State:     model.UserStateActive,
+	CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	UpdatedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+}
+
+func TestRepositoryUser(t *testing.T) {
+	tests := map[string]struct {
+		calls          []testCall
+		exec           func(repo *scim.Repository) (*model.User, error)
+		expUser        *model.User
+		expErr         bool
+		expErrNotFound bool
+		expErrNotSupp  bool
+	}{
+		"Creating a user correctly, should return the data with the ID.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPost,
+					path:       "/Users",
+					body:       `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"test@slok.dev","displayName":"test00","emails":[{"value":"test@slok.dev","primary":true}],"active":true}`,
+					respStatus: http.StatusCreated,
+					respBody:   testSCIMUser00,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.CreateUser(context.TODO(), model.User{Email: "test@slok.dev", Name: "test00"})
+			},
+			expUser: &testModelUser00,
+		},
+
+		"Creating a guest user, should fail as not supported.": {
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.CreateUser(context.TODO(), model.User{Email: "test@slok.dev", Name: "test00", Type: model.UserTypeGuest})
+			},
+			expErr:        true,
+			expErrNotSupp: true,
+		},
+
+		"Having an error while creating a user, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPost,
+					path:       "/Users",
+					body:       `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"test@slok.dev","displayName":"test00","emails":[{"value":"test@slok.dev","primary":true}],"active":true}`,
+					respStatus: http.StatusConflict,
+					respBody:   `{"detail":"user already exists","status":"409"}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.CreateUser(context.TODO(), model.User{Email: "test@slok.dev", Name: "test00"})
+			},
+			expErr: true,
+		},
+
+		"Getting a user by ID, should return the user.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Users/1234567890", respStatus: http.StatusOK, respBody: testSCIMUser00},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.GetUserByID(context.TODO(), "1234567890")
+			},
+			expUser: &testModelUser00,
+		},
+
+		"Getting a missing user by ID, should fail with not found.": {
+			calls: []testCall{
+				{method: http.MethodGet, path: "/Users/1234567890", respStatus: http.StatusNotFound, respBody: `{"detail":"user not found","status":"404"}`},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.GetUserByID(context.TODO(), "1234567890")
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Getting a user by email, should filter the users by user name.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Users?filter=userName eq "test@slok.dev"&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":1,"startIndex":1,"itemsPerPage":2,"Resources":[` + testSCIMUser00 + `]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.GetUserByEmail(context.TODO(), "test@slok.dev")
+			},
+			expUser: &testModelUser00,
+		},
+
+		"Getting a missing user by email, should fail with not found.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       `/Users?filter=userName eq "test@slok.dev"&startIndex=1&count=2`,
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":0,"startIndex":1,"itemsPerPage":2,"Resources":[]}`,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.GetUserByEmail(context.TODO(), "test@slok.dev")
+			},
+			expErr:         true,
+			expErrNotFound: true,
+		},
+
+		"Ensuring a user, should patch the user name and email.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Users/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"userName","value":"test@slok.dev"},{"op":"replace","path":"displayName","value":"test00"},{"op":"replace","path":"emails","value":[{"value":"test@slok.dev","primary":true}]}]}`,
+					respStatus: http.StatusOK,
+					respBody:   testSCIMUser00,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.EnsureUser(context.TODO(), model.User{ID: "1234567890", Email: "test@slok.dev", Name: "test00"})
+			},
+			expUser: &testModelUser00,
+		},
+
+		"Ensuring a user on a server that doesn't return the patched user, should get the user again.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Users/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"userName","value":"test@slok.dev"},{"op":"replace","path":"displayName","value":"test00"},{"op":"replace","path":"emails","value":[{"value":"test@slok.dev","primary":true}]}]}`,
+					respStatus: http.StatusNoContent,
+				},
+				{method: http.MethodGet, path: "/Users/1234567890", respStatus: http.StatusOK, respBody: testSCIMUser00},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return repo.EnsureUser(context.TODO(), model.User{ID: "1234567890", Email: "test@slok.dev", Name: "test00"})
+			},
+			expUser: &testModelUser00,
+		},
+
+		"Suspending a user, should deactivate the user.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Users/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"active","value":false}]}`,
+					respStatus: http.StatusOK,
+					respBody:   testSCIMUser00,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return nil, repo.SuspendUser(context.TODO(), "1234567890")
+			},
+		},
+
+		"Reactivating a user, should activate the user.": {
+			calls: []testCall{
+				{
+					method:     http.MethodPatch,
+					path:       "/Users/1234567890",
+					body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"active","value":true}]}`,
+					respStatus: http.StatusOK,
+					respBody:   testSCIMUser00,
+				},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return nil, repo.ReactivateUser(context.TODO(), "1234567890")
+			},
+		},
+
+		"Deleting a user, should delete the user.": {
+			calls: []testCall{
+				{method: http.MethodDelete, path: "/Users/1234567890", respStatus: http.StatusNoContent},
+			},
+			exec: func(repo *scim.Repository) (*model.User, error) {
+				return nil, repo.DeleteUser(context.TODO(), "1234567890")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			gotUser, err := test.exec(repo)
+
+			if test.expErr {
+				require.Error(err)
+				if test.expErrNotFound {
+					assert.ErrorIs(err, storage.ErrNotFound)
+				}
+				if test.expErrNotSupp {
+					assert.ErrorIs(err, storage.ErrNotSupported)
+				}
+			} else if assert.NoError(err) {
+				assert.Equal(test.expUser, gotUser)
+			}
+		})
+	}
+}
+
+func TestRepositoryListUsers(t *testing.T) {
+	tests := map[string]struct {
+		calls    []testCall
+		expUsers []model.User
+		expErr   bool
+	}{
+		"Listing users, should get all the pages.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=1&count=2",
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":3,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"u0","userName":"u0@slok.dev","displayName":"u0","active":true},{"id":"u1","userName":"u1@slok.dev","displayName":"u1","active":false}]}`,
+				},
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=3&count=2",
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":3,"startIndex":3,"itemsPerPage":1,"Resources":[{"id":"u2","userName":"u2@slok.dev","displayName":"u2","active":true}]}`,
+				},
+			},
+			expUsers: []model.User{
+				{ID: "u0", Email: "u0@slok.dev", Name: "u0", Type: model.UserTypeMember, State: model.UserStateActive},
+				{ID: "u1", Email: "u1@slok.dev", Name: "u1", Type: model.UserTypeMember, State: model.UserStateSuspended},
+				{ID: "u2", Email: "u2@slok.dev", Name: "u2", Type: model.UserTypeMember, State: model.UserStateActive},
+			},
+		},
+
+		"A server that doesn't advance the pagination, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=1&count=2",
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":5,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"u0","userName":"u0@slok.dev","displayName":"u0","active":true},{"id":"u1","userName":"u1@slok.dev","displayName":"u1","active":true}]}`,
+				},
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=3&count=2",
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":5,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"u0","userName":"u0@slok.dev","displayName":"u0","active":true},{"id":"u1","userName":"u1@slok.dev","displayName":"u1","active":true}]}`,
+				},
+			},
+			expErr: true,
+		},
+
+		"Having an error while listing a page, should fail.": {
+			calls: []testCall{
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=1&count=2",
+					respStatus: http.StatusOK,
+					respBody:   `{"totalResults":3,"startIndex":1,"itemsPerPage":2,"Resources":[{"id":"u0","userName":"u0@slok.dev"},{"id":"u1","userName":"u1@slok.dev"}]}`,
+				},
+				{
+					method:     http.MethodGet,
+					path:       "/Users?startIndex=3&count=2",
+					respStatus: http.StatusInternalServerError,
+				},
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			repo := newTestRepository(t, test.calls)
+
+			gotUsers, err := repo.ListUsers(context.TODO())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expUsers, gotUsers)
+			}
+		})
+	}
+}
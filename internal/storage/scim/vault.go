@@ -0,0 +1,69 @@
+package scim
+
+import (
+	"context"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+)
+
+// SCIM doesn't know about vaults, all the vault operations are unsupported.
+
+func (r Repository) CreateVault(ctx context.Context, vault model.Vault) (*model.Vault, error) {
+	return nil, errNotSupported("vault management")
+}
+
+func (r Repository) GetVaultByID(ctx context.Context, id string) (*model.Vault, error) {
+	return nil, errNotSupported("vault management")
+}
+
+func (r Repository) GetVaultByName(ctx context.Context, name string) (*model.Vault, error) {
+	return nil, errNotSupported("vault management")
+}
+
+func (r Repository) EnsureVault(ctx context.Context, vault model.Vault) (*model.Vault, error) {
+	return nil, errNotSupported("vault management")
+}
+
+func (r Repository) DeleteVault(ctx context.Context, id string) error {
+	return errNotSupported("vault management")
+}
+
+func (r Repository) CountVaultItems(ctx context.Context, id string) (int, error) {
+	return 0, errNotSupported("vault management")
+}
+
+func (r Repository) ListVaults(ctx context.Context) ([]model.Vault, error) {
+	return nil, errNotSupported("vault management")
+}
+
+func (r Repository) EnsureVaultGroupAccess(ctx context.Context, groupAccess model.VaultGroupAccess) error {
+	return errNotSupported("vault access management")
+}
+
+func (r Repository) DeleteVaultGroupAccess(ctx context.Context, vaultID string, groupID string) error {
+	return errNotSupported("vault access management")
+}
+
+func (r Repository) GetVaultGroupAccessByID(ctx context.Context, vaultID string, groupID string) (*model.VaultGroupAccess, error) {
+	return nil, errNotSupported("vault access management")
+}
+
+func (r Repository) ListVaultGroupAccesses(ctx context.Context, vaultID string) ([]model.VaultGroupAccess, error) {
+	return nil, errNotSupported("vault access management")
+}
+
+func (r Repository) EnsureVaultUserAccess(ctx context.Context, userAccess model.VaultUserAccess) error {
+	return errNotSupported("vault access management")
+}
+
+func (r Repository) DeleteVaultUserAccess(ctx context.Context, vaultID string, userID string) error {
+	return errNotSupported("vault access management")
+}
+
+func (r Repository) GetVaultUserAccessByID(ctx context.Context, vaultID string, userID string) (*model.VaultUserAccess, error) {
+	return nil, errNotSupported("vault access management")
+}
+
+func (r Repository) ListVaultUserAccesses(ctx context.Context, vaultID string) ([]model.VaultUserAccess, error) {
+	return nil, errNotSupported("vault access management")
+}
@@ -0,0 +1,24 @@
+package scim_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage"
+)
+
+func TestRepositoryVaultNotSupported(t *testing.T) {
+	repo := newTestRepository(t, nil)
+
+	_, err := repo.CreateVault(context.TODO(), model.Vault{Name: "test-00"})
+	assert.ErrorIs(t, err, storage.ErrNotSupported)
+
+	err = repo.EnsureVaultGroupAccess(context.TODO(), model.VaultGroupAccess{VaultID: "vault-00", GroupID: "group-00"})
+	assert.ErrorIs(t, err, storage.ErrNotSupported)
+
+	err = repo.EnsureVaultUserAccess(context.TODO(), model.VaultUserAccess{VaultID: "vault-00", UserID: "user-00"})
+	assert.ErrorIs(t, err, storage.ErrNotSupported)
+}
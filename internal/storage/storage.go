@@ -2,38 +2,59 @@ package storage
 
 import (
 	"context"
+	"errors"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/model"
 )
 
+// ErrNotFound is returned by the repositories when the requested resource is missing.
+//
+// Use `errors.Is` to check it, repositories will wrap it with more context.
+var ErrNotFound = errors.New("not found")
+
+// ErrNotSupported is returned by the repositories when the requested operation can't be done by the repository
+// (e.g: the op CLI doesn't have a command for it).
+//
+// Use `errors.Is` to check it, repositories will wrap it with more context.
+var ErrNotSupported = errors.New("not supported")
+
 type Repository interface {
 	CreateUser(ctx context.Context, user model.User) (*model.User, error)
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 	EnsureUser(ctx context.Context, user model.User) (*model.User, error)
 	DeleteUser(ctx context.Context, id string) error
+	SuspendUser(ctx context.Context, id string) error
+	ReactivateUser(ctx context.Context, id string) error
+	ListUsers(ctx context.Context) ([]model.User, error)
 
 	CreateGroup(ctx context.Context, group model.Group) (*model.Group, error)
 	GetGroupByID(ctx context.Context, id string) (*model.Group, error)
 	GetGroupByName(ctx context.Context, name string) (*model.Group, error)
 	EnsureGroup(ctx context.Context, group model.Group) (*model.Group, error)
 	DeleteGroup(ctx context.Context, id string) error
+	ListGroups(ctx context.Context) ([]model.Group, error)
 
 	CreateVault(ctx context.Context, vault model.Vault) (*model.Vault, error)
 	GetVaultByID(ctx context.Context, id string) (*model.Vault, error)
 	GetVaultByName(ctx context.Context, name string) (*model.Vault, error)
 	EnsureVault(ctx context.Context, vault model.Vault) (*model.Vault, error)
 	DeleteVault(ctx context.Context, id string) error
+	CountVaultItems(ctx context.Context, id string) (int, error)
+	ListVaults(ctx context.Context) ([]model.Vault, error)
 
 	EnsureMembership(ctx context.Context, membership model.Membership) error
 	DeleteMembership(ctx context.Context, membership model.Membership) error
 	GetMembershipByID(ctx context.Context, groupID, userID string) (*model.Membership, error)
+	ListGroupMembers(ctx context.Context, groupID string) ([]model.GroupMember, error)
 
 	EnsureVaultGroupAccess(ctx context.Context, groupAccess model.VaultGroupAccess) error
 	DeleteVaultGroupAccess(ctx context.Context, vaultID string, groupID string) error
 	GetVaultGroupAccessByID(ctx context.Context, vaultID string, groupID string) (*model.VaultGroupAccess, error)
+	ListVaultGroupAccesses(ctx context.Context, vaultID string) ([]model.VaultGroupAccess, error)
 
 	EnsureVaultUserAccess(ctx context.Context, userAccess model.VaultUserAccess) error
 	DeleteVaultUserAccess(ctx context.Context, vaultID string, userID string) error
 	GetVaultUserAccessByID(ctx context.Context, vaultID string, userID string) (*model.VaultUserAccess, error)
+	ListVaultUserAccesses(ctx context.Context, vaultID string) ([]model.VaultUserAccess, error)
 }
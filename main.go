@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/slok/terraform-provider-onepasswordorg/internal/provider"
+	"github.com/slok/terraform-provider-onepasswordorg/internal/storage/onepasswordcli"
 )
 
 const providerName = "registry.terraform.io/slok/onepasswordorg"
@@ -20,6 +22,13 @@ func run(ctx context.Context) error {
 		Address: providerName,
 	})
 
+	// Signout and remove the op configuration of the provider before exiting.
+	cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cleanupCancel()
+	if cerr := onepasswordcli.Cleanup(cleanupCtx); cerr != nil {
+		fmt.Fprintf(os.Stderr, "Error cleaning up op CLI: %s\n", cerr)
+	}
+
 	return err
 }
 